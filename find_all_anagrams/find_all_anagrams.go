@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Find All Anagrams in a String (LeetCode 438) / Permutation in String (LeetCode 567)
+// Slide a window of len(pattern) over text and keep the window's letter counts
+// up to date instead of recounting: one letter enters, one leaves per step.
+
+// OPTIMAL SOLUTION: Sliding Window with [26]int counter (lowercase a-z)
+// diff[c] = (count of c in pattern) - (count of c in window); the window is an
+// anagram when every entry is zero, tracked by the number of non-zero entries
+// Time Complexity: O(n + m), Space Complexity: O(1) - not counting the output
+func FindAnagrams(text, pattern string) []int {
+	if !isLowercaseASCII(text) || !isLowercaseASCII(pattern) {
+		return findAnagramsUnicode(text, pattern)
+	}
+
+	result := []int{}
+	m := len(pattern)
+	if m == 0 || m > len(text) {
+		return result
+	}
+
+	diff := [26]int{}
+	for i := 0; i < m; i++ {
+		diff[pattern[i]-'a']++
+	}
+	nonZero := 0
+	for _, d := range diff {
+		if d != 0 {
+			nonZero++
+		}
+	}
+
+	// update adjusts one counter and keeps nonZero in sync
+	update := func(c byte, delta int) {
+		before := diff[c-'a']
+		diff[c-'a'] += delta
+		if before == 0 {
+			nonZero++
+		} else if diff[c-'a'] == 0 {
+			nonZero--
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		update(text[i], -1) // Character enters the window
+		if i >= m {
+			update(text[i-m], +1) // Character leaves the window
+		}
+		if i >= m-1 && nonZero == 0 {
+			result = append(result, i-m+1)
+		}
+	}
+
+	return result
+}
+
+// ALTERNATIVE: Sliding Window with map counter (any Unicode text)
+// Same idea with runes: the window holds len(pattern) runes, and the returned
+// indices are byte offsets into text, so text[i:] starts at the match
+// Time Complexity: O(n + m), Space Complexity: O(m) - distinct runes of pattern
+func findAnagramsUnicode(text, pattern string) []int {
+	result := []int{}
+	m := utf8.RuneCountInString(pattern)
+	if m == 0 {
+		return result
+	}
+
+	w := newRuneWindow(pattern)
+	for offset, r := range text {
+		if w.push(r, int64(offset)) {
+			result = append(result, int(w.start()))
+		}
+	}
+
+	return result
+}
+
+// checkInclusion reports whether text contains a permutation of pattern (LeetCode 567)
+func checkInclusion(pattern, text string) bool {
+	return len(FindAnagrams(text, pattern)) > 0
+}
+
+// FindAnagramsReader streams text from r and reports the byte offset of every
+// window that is a permutation of pattern. Only the last len(pattern) runes
+// are buffered, so the text can be arbitrarily large.
+// Time Complexity: O(n + m), Space Complexity: O(m)
+func FindAnagramsReader(r io.Reader, pattern string) ([]int64, error) {
+	result := []int64{}
+	if pattern == "" {
+		return result, nil
+	}
+
+	reader := bufio.NewReader(r)
+	w := newRuneWindow(pattern)
+	var offset int64
+	for {
+		char, size, err := reader.ReadRune()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("find anagrams: reading text at byte %d: %w", offset, err)
+		}
+		if w.push(char, offset) {
+			result = append(result, w.start())
+		}
+		offset += int64(size)
+	}
+}
+
+// runeWindow is the map-based sliding window shared by the Unicode and reader versions
+type runeWindow struct {
+	diff    map[rune]int // pattern count - window count, zero entries deleted
+	runes   []rune       // ring buffer of the runes in the window
+	offsets []int64      // byte offset of each rune in the ring buffer
+	next    int          // ring position the next rune is written to
+	filled  int          // how many runes the window holds (up to len(runes))
+}
+
+func newRuneWindow(pattern string) *runeWindow {
+	w := &runeWindow{diff: make(map[rune]int)}
+	for _, r := range pattern {
+		w.diff[r]++
+	}
+	m := utf8.RuneCountInString(pattern)
+	w.runes, w.offsets = make([]rune, m), make([]int64, m)
+	return w
+}
+
+// push adds a rune at the given byte offset, evicting the oldest once the
+// window is full, and reports whether the window is now an anagram of pattern
+func (w *runeWindow) push(r rune, offset int64) bool {
+	if w.filled == len(w.runes) {
+		w.adjust(w.runes[w.next], +1) // Oldest rune leaves the window
+	} else {
+		w.filled++
+	}
+	w.adjust(r, -1)
+	w.runes[w.next], w.offsets[w.next] = r, offset
+	w.next = (w.next + 1) % len(w.runes)
+
+	return w.filled == len(w.runes) && len(w.diff) == 0
+}
+
+// start is the byte offset of the oldest rune in a full window
+func (w *runeWindow) start() int64 {
+	return w.offsets[w.next]
+}
+
+func (w *runeWindow) adjust(r rune, delta int) {
+	w.diff[r] += delta
+	if w.diff[r] == 0 {
+		delete(w.diff, r)
+	}
+}
+
+func isLowercaseASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Brute force reference: recount every window from scratch
+// Offsets come from ranging over text, so an invalid byte counts as 1 byte
+// (utf8.RuneLen of its U+FFFD replacement would say 3)
+// Time Complexity: O(n * m)
+func findAnagramsBruteForce(text, pattern string) []int {
+	result := []int{}
+	patternRunes := []rune(pattern)
+	m := len(patternRunes)
+	if m == 0 {
+		return result
+	}
+
+	var textRunes []rune
+	var offsets []int
+	for offset, r := range text {
+		textRunes = append(textRunes, r)
+		offsets = append(offsets, offset)
+	}
+
+	for i := 0; i+m <= len(textRunes); i++ {
+		counts := make(map[rune]int)
+		for _, r := range patternRunes {
+			counts[r]++
+		}
+		for _, r := range textRunes[i : i+m] {
+			counts[r]--
+		}
+		match := true
+		for _, c := range counts {
+			if c != 0 {
+				match = false
+			}
+		}
+		if match {
+			result = append(result, offsets[i])
+		}
+	}
+	return result
+}
+
+func main() {
+	fmt.Println("=== Find All Anagrams in a String ===")
+	fmt.Println("Return every start index where a permutation of pattern occurs")
+	fmt.Println()
+
+	testCases := []struct {
+		text, pattern string
+		expected      []int
+		name          string
+	}{
+		{"cbaebabacd", "abc", []int{0, 6}, "Example 1"},
+		{"abab", "ab", []int{0, 1, 2}, "Example 2: overlapping matches"},
+		{"aaaa", "aa", []int{0, 1, 2}, "Repeated letter"},
+		{"abc", "abcd", []int{}, "Pattern longer than text"},
+		{"abc", "", []int{}, "Empty pattern"},
+		{"Listen, Silent!", "net", []int{3, 11}, "Non-lowercase text (map window)"},
+		{"añoaño", "oña", []int{0, 1, 3, 4}, "Multi-byte runes (byte offsets)"},
+		{"\xffab\xffba", "ba", []int{1, 4}, "Invalid UTF-8 byte (1 byte wide)"},
+	}
+
+	for i, tc := range testCases {
+		result := FindAnagrams(tc.text, tc.pattern)
+		reference := findAnagramsBruteForce(tc.text, tc.pattern)
+		streamed, err := FindAnagramsReader(strings.NewReader(tc.text), tc.pattern)
+
+		status := "✅"
+		if fmt.Sprint(result) != fmt.Sprint(tc.expected) ||
+			fmt.Sprint(reference) != fmt.Sprint(tc.expected) ||
+			fmt.Sprint(streamed) != fmt.Sprint(tc.expected) || err != nil {
+			status = "❌"
+		}
+		fmt.Printf("Test %d (%s): text=%q pattern=%q -> %v %s\n",
+			i+1, tc.name, tc.text, tc.pattern, result, status)
+	}
+
+	fmt.Println("\n=== Permutation in String (LeetCode 567) ===")
+	fmt.Printf("checkInclusion(\"ab\", \"eidbaooo\") = %v\n", checkInclusion("ab", "eidbaooo"))
+	fmt.Printf("checkInclusion(\"ab\", \"eidboaoo\") = %v\n", checkInclusion("ab", "eidboaoo"))
+
+	fmt.Println("\n=== Streaming a Large Text from an io.Reader ===")
+	// 1 MB of text generated on the fly, never held in memory as a whole
+	chunk := "the quick brown fox jumps over the lazy dog "
+	large := io.LimitReader(&repeatReader{chunk: chunk}, 1<<20)
+	offsets, err := FindAnagramsReader(large, "god")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Found %d windows matching \"god\" in 1 MB, first at byte %d\n", len(offsets), offsets[0])
+
+	fmt.Println("\n=== Complexity ===")
+	fmt.Println("• [26]int window: O(n + m) time, O(1) space - lowercase a-z")
+	fmt.Println("• map window:     O(n + m) time, O(m) space - any Unicode text or io.Reader")
+	fmt.Println("• Brute force:    O(n * m) time - recount every window")
+}
+
+// repeatReader yields chunk over and over, for generating large inputs
+type repeatReader struct {
+	chunk string
+	pos   int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		copied := copy(p[n:], r.chunk[r.pos:])
+		n += copied
+		r.pos = (r.pos + copied) % len(r.chunk)
+	}
+	return n, nil
+}
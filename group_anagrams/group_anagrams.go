@@ -1,13 +1,42 @@
 package main
 
-import "fmt"
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
 
+// Groups are returned in order of first appearance and each group keeps its
+// words in input order, so the output is the same on every run
 func groupAnagrams(strs []string) [][]string {
-	// Map to group strings by their character frequency signature
-	// Using [26]byte instead of [26]int for better memory efficiency
-	group := make(map[[26]byte][]string)
+	// Map each character frequency signature to its group's position in result
+	// Using [26]byte instead of [26]int for better memory efficiency.
+	// A byte count is exact only up to 255, so longer words (which could
+	// wrap a count back to 0) are keyed by their exact encoded counts instead.
+	group := make(map[[26]byte]int)
+	longGroup := make(map[string]int)
+	result := [][]string{}
 
 	for _, str := range strs {
+		if len(str) > maxByteCount {
+			result = addToGroup(result, longGroup, countSignature(str), str)
+			continue
+		}
+
 		// Create frequency count array for current string
 		freq := [26]byte{}
 		for _, char := range str {
@@ -15,40 +44,826 @@ func groupAnagrams(strs []string) [][]string {
 		}
 
 		// Group strings with same frequency signature
-		group[freq] = append(group[freq], str)
+		result = addToGroup(result, group, freq, str)
 	}
 
-	// Convert map values to result slice
-	result := [][]string{}
-	for _, anagramGroup := range group {
-		result = append(result, anagramGroup)
+	return result
+}
+
+// addToGroup appends str to the group for key, opening a new group at the end
+// of result the first time key is seen (this is what keeps the order stable)
+func addToGroup[K comparable](result [][]string, index map[K]int, key K, str string) [][]string {
+	if i, exists := index[key]; exists {
+		result[i] = append(result[i], str)
+		return result
 	}
+	index[key] = len(result)
+	return append(result, []string{str})
+}
 
-	return result
+// maxByteCount is the longest word whose letter counts always fit in a byte
+const maxByteCount = 255
+
+// countSignature encodes the exact a-z counts of str, for words of any length
+// Each count is written as a uvarint, so the key grows with the counts
+// instead of wrapping around like a byte would
+func countSignature(str string) string {
+	counts := [26]int{}
+	for _, char := range str {
+		counts[char-'a']++
+	}
+	return encodeCounts(counts)
 }
 
-// Alternative version with overflow protection (if needed)
+// encodeCounts is the key countSignature builds from a-z counts
+func encodeCounts(counts [26]int) string {
+	key := make([]byte, 0, 2*len(counts))
+	for _, count := range counts {
+		key = binary.AppendUvarint(key, uint64(count))
+	}
+	return string(key)
+}
+
+// Alternative version with int counts - exact for any length, but each key
+// is 26 ints (208 bytes) instead of 26 bytes
 func groupAnagramsSafe(strs []string) [][]string {
-	group := make(map[[26]byte][]string)
+	group := make(map[[26]int]int)
+	result := [][]string{}
 
 	for _, str := range strs {
-		freq := [26]byte{}
+		freq := [26]int{}
 		for _, char := range str {
-			if freq[char-'a'] < 255 { // Prevent overflow
-				freq[char-'a']++
+			freq[char-'a']++
+		}
+		result = addToGroup(result, group, freq, str)
+	}
+
+	return result
+}
+
+// Unicode-aware version: works for any input, not just lowercase a-z
+// Key: the word's runes in sorted order, optionally case folded first.
+// Falls back to the [26]byte path when every word is pure lowercase ASCII.
+// Time Complexity: O(n * k log k) where k is the word length (O(n * k) on the fast path)
+// Space Complexity: O(n * k) for the keys
+func groupAnagramsUnicode(strs []string, foldCase bool) [][]string {
+	if allLowercaseASCII(strs) {
+		return groupAnagrams(strs)
+	}
+
+	group := make(map[string]int)
+	result := [][]string{}
+	for _, str := range strs {
+		result = addToGroup(result, group, runeSignature(str, foldCase), str)
+	}
+
+	return result
+}
+
+// anagramKey is groupAnagrams' signature as a single comparable value:
+// the [26]byte counts for short words, the exact encoded counts for long ones
+type anagramKey struct {
+	freq [26]byte
+	long string
+}
+
+func signatureOf(str string) anagramKey {
+	if len(str) > maxByteCount {
+		return anagramKey{long: countSignature(str)}
+	}
+	key := anagramKey{}
+	for _, char := range str {
+		key.freq[char-'a']++
+	}
+	return key
+}
+
+// Parallel sharded version for very large inputs
+// Phase 1: workers compute signatures for contiguous chunks of the input and
+// route each word to a shard chosen by hashing its signature.
+// Phase 2: each shard is grouped by exactly one worker with its own map - all
+// words of an anagram group land in the same shard, so no locks are needed.
+// Phase 3: groups from all shards are merged by the index of their first word,
+// giving exactly groupAnagrams' order (first appearance, members in input order).
+// Time Complexity: O(n * k / workers + g log g), Space Complexity: O(n)
+func groupAnagramsParallel(strs []string, workers int) [][]string {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	shards := workers
+	chunk := (len(strs) + workers - 1) / workers
+	if chunk == 0 {
+		return [][]string{}
+	}
+	seed := maphash.MakeSeed()
+
+	// Phase 1: keys[i] is the signature of strs[i]; routed[c][s] lists the
+	// indices from chunk c that belong to shard s, in input order
+	keys := make([]anagramKey, len(strs))
+	chunks := (len(strs) + chunk - 1) / chunk
+	routed := make([][][]int, chunks)
+
+	var wg sync.WaitGroup
+	for c := 0; c < chunks; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			routed[c] = make([][]int, shards)
+			for i := c * chunk; i < min((c+1)*chunk, len(strs)); i++ {
+				keys[i] = signatureOf(strs[i])
+				shard := maphash.Comparable(seed, keys[i]) % uint64(shards)
+				routed[c][shard] = append(routed[c][shard], i)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	// Phase 2: group each shard independently, visiting chunks in order so
+	// members stay in input order
+	type shardGroup struct {
+		first int // index of the group's first word in strs
+		words []string
+	}
+	shardGroups := make([][]shardGroup, shards)
+	for s := 0; s < shards; s++ {
+		wg.Add(1)
+		go func(s int) {
+			defer wg.Done()
+			group := make(map[anagramKey]int)
+			for c := range routed {
+				for _, i := range routed[c][s] {
+					if g, exists := group[keys[i]]; exists {
+						shardGroups[s][g].words = append(shardGroups[s][g].words, strs[i])
+					} else {
+						group[keys[i]] = len(shardGroups[s])
+						shardGroups[s] = append(shardGroups[s], shardGroup{first: i, words: []string{strs[i]}})
+					}
+				}
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	// Phase 3: deterministic merge by first appearance
+	var all []shardGroup
+	for _, groups := range shardGroups {
+		all = append(all, groups...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].first < all[j].first
+	})
+
+	result := make([][]string, len(all))
+	for i, g := range all {
+		result[i] = g.words
+	}
+	return result
+}
+
+// streamPartitions is how many spill files groupAnagramsStream hashes groups
+// into, and how many sub-partitions an oversized spill file is split into
+const streamPartitions = 16
+
+// maxSpillLevel bounds how often a spill file is re-partitioned; 16^8 files
+// is far more than any input needs, this only guards against a bad hash
+const maxSpillLevel = 8
+
+// wordOverhead approximates the bookkeeping memory per buffered word
+// (string header plus its share of the group slice)
+const wordOverhead = 24
+
+// streamGroup is one anagram group buffered by groupAnagramsStream
+type streamGroup struct {
+	first int // position of the group's first word in the input
+	words []string
+}
+
+// spillPartition is one spill file of "position word" lines
+type spillPartition struct {
+	file   *os.File
+	writer *bufio.Writer
+	cost   int // memory needed to load it back, same estimate as the budget
+}
+
+func (p *spillPartition) write(positionText, word string) error {
+	p.cost += len(word) + wordOverhead
+	if _, err := fmt.Fprintf(p.writer, "%s %s\n", positionText, word); err != nil {
+		return fmt.Errorf("group stream: writing spill file: %w", err)
+	}
+	return nil
+}
+
+// remove closes and deletes the spill file once its lines are no longer needed
+func (p *spillPartition) remove() {
+	if p.file != nil {
+		p.file.Close()
+		os.Remove(p.file.Name())
+		p.file = nil
+	}
+}
+
+// Streaming version for word lists larger than memory
+// Reads whitespace-separated lowercase words from r and writes one group per
+// line (words separated by spaces) to w. Groups are buffered in memory; when
+// they exceed memoryBudget bytes, they are spilled to temporary files in
+// tempDir ("" = os.TempDir) partitioned by signature hash. A spill file that
+// is still larger than the budget is split again with a hash of the next
+// level, so only a single anagram group bigger than the budget can exceed it.
+// If nothing was spilled, the output is in groupAnagrams order. With spills
+// the output is ordered per partition, not globally: partitions follow each
+// other in hash order, and within a partition groups are in order of first
+// appearance. Either way the same input always gives the same output.
+// Time Complexity: O(n * k * levels), Space Complexity: O(memoryBudget + largest group)
+func groupAnagramsStream(r io.Reader, w io.Writer, memoryBudget int, tempDir string) (err error) {
+	// Partitions are removed as soon as they are emitted or split; this only
+	// cleans up what is left when returning early with an error
+	var created []*spillPartition
+	defer func() {
+		for _, p := range created {
+			p.remove()
+		}
+	}()
+	newPartition := func() (*spillPartition, error) {
+		f, err := os.CreateTemp(tempDir, "anagrams-*.spill")
+		if err != nil {
+			return nil, fmt.Errorf("group stream: creating spill file: %w", err)
+		}
+		p := &spillPartition{file: f, writer: bufio.NewWriter(f)}
+		created = append(created, p)
+		return p, nil
+	}
+
+	var spills [streamPartitions]*spillPartition
+	index := make(map[anagramKey]int)
+	var groups []streamGroup
+	used := 0
+
+	// spill appends every buffered word as "position word" to its partition file
+	spill := func() error {
+		for key, g := range index {
+			p := stableHash(key, 0) % streamPartitions
+			if spills[p] == nil {
+				partition, err := newPartition()
+				if err != nil {
+					return err
+				}
+				spills[p] = partition
+			}
+			// Only the first word needs its real position, the rest keep file order
+			for i, word := range groups[g].words {
+				position := groups[g].first
+				if i > 0 {
+					position = -1
+				}
+				if err := spills[p].write(strconv.Itoa(position), word); err != nil {
+					return err
+				}
 			}
 		}
-		group[freq] = append(group[freq], str)
+		clear(index)
+		groups, used = groups[:0], 0
+		return nil
 	}
 
-	result := make([][]string, 0, len(group))
-	for _, anagramGroup := range group {
-		result = append(result, anagramGroup)
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for position := 0; scanner.Scan(); position++ {
+		word := scanner.Text()
+		if !allLowercaseASCII([]string{word}) {
+			return fmt.Errorf("group stream: word %d %q is not lowercase a-z", position+1, word)
+		}
+
+		key := signatureOf(word)
+		if g, exists := index[key]; exists {
+			groups[g].words = append(groups[g].words, word)
+		} else {
+			index[key] = len(groups)
+			groups = append(groups, streamGroup{first: position, words: []string{word}})
+		}
+
+		used += len(word) + wordOverhead
+		if used > memoryBudget {
+			if err := spill(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("group stream: reading words: %w", err)
+	}
+
+	out := bufio.NewWriter(w)
+	if len(created) == 0 {
+		// Everything fit in memory: groups are already in first-appearance order
+		if err := writeGroups(out, groups); err != nil {
+			return err
+		}
+		return out.Flush()
+	}
+
+	if err := spill(); err != nil {
+		return err
+	}
+
+	// emit loads a partition that fits the budget, or splits it and recurses
+	var emit func(p *spillPartition, level int) error
+	emit = func(p *spillPartition, level int) error {
+		if err := p.writer.Flush(); err != nil {
+			return fmt.Errorf("group stream: writing spill file: %w", err)
+		}
+		if p.cost > memoryBudget && level < maxSpillLevel {
+			parts, split, err := splitPartition(p, level+1, newPartition)
+			if err != nil {
+				return err
+			}
+			if split {
+				// Every line now lives in a sub-partition, so open files stay
+				// around streamPartitions per level instead of growing with the input
+				p.remove()
+				for _, part := range parts {
+					if part != nil {
+						if err := emit(part, level+1); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}
+			// One anagram group on its own: splitting cannot make it smaller
+			for _, part := range parts {
+				if part != nil {
+					part.remove()
+				}
+			}
+		}
+
+		partition, err := readPartition(p.file)
+		if err != nil {
+			return err
+		}
+		if err := writeGroups(out, partition); err != nil {
+			return err
+		}
+		p.remove()
+		return nil
+	}
+	for _, p := range spills {
+		if p != nil {
+			if err := emit(p, 0); err != nil {
+				return err
+			}
+		}
+	}
+	return out.Flush()
+}
+
+// splitPartition rehashes the lines of p into up to streamPartitions new files
+// using the hash of the given level. Lines keep their file order, so each
+// group's first line still carries its real position. split is false when p
+// holds a single anagram group, which no hash can spread out.
+func splitPartition(p *spillPartition, level int, newPartition func() (*spillPartition, error)) (
+	parts [streamPartitions]*spillPartition, split bool, err error) {
+	if _, err := p.file.Seek(0, io.SeekStart); err != nil {
+		return parts, false, fmt.Errorf("group stream: rewinding spill file: %w", err)
+	}
+
+	var firstKey anagramKey
+	scanner := bufio.NewScanner(p.file)
+	for lines := 0; scanner.Scan(); lines++ {
+		positionText, word, found := strings.Cut(scanner.Text(), " ")
+		if !found {
+			return parts, false, fmt.Errorf("group stream: corrupt spill line %q", scanner.Text())
+		}
+
+		key := signatureOf(word)
+		if lines == 0 {
+			firstKey = key
+		} else if key != firstKey {
+			split = true
+		}
+		q := stableHash(key, level) % streamPartitions
+		if parts[q] == nil {
+			if parts[q], err = newPartition(); err != nil {
+				return parts, false, err
+			}
+		}
+		if err := parts[q].write(positionText, word); err != nil {
+			return parts, false, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return parts, false, fmt.Errorf("group stream: reading spill file: %w", err)
+	}
+	return parts, split, nil
+}
+
+// stableHash is FNV-1a over a signature - unlike maphash it is the same on
+// every run, so spilled groups land in the same partitions every time.
+// The level is mixed in with a splitmix64 finalizer: FNV's low bits alone
+// would send a partition that is split again into a single sub-partition.
+func stableHash(key anagramKey, level int) uint64 {
+	const offset, prime = 14695981039346656037, 1099511628211
+	hash := uint64(offset)
+	for _, b := range key.freq {
+		hash = (hash ^ uint64(b)) * prime
+	}
+	for i := 0; i < len(key.long); i++ {
+		hash = (hash ^ uint64(key.long[i])) * prime
+	}
+
+	hash += uint64(level) * 0x9e3779b97f4a7c15
+	hash = (hash ^ hash>>30) * 0xbf58476d1ce4e5b9
+	hash = (hash ^ hash>>27) * 0x94d049bb133111eb
+	return hash ^ hash>>31
+}
+
+// readPartition regroups one spill file, ordered by first appearance
+func readPartition(f *os.File) ([]streamGroup, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("group stream: rewinding spill file: %w", err)
+	}
+
+	index := make(map[anagramKey]int)
+	var groups []streamGroup
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		positionText, word, found := strings.Cut(scanner.Text(), " ")
+		if !found {
+			return nil, fmt.Errorf("group stream: corrupt spill line %q", scanner.Text())
+		}
+
+		key := signatureOf(word)
+		if g, exists := index[key]; exists {
+			groups[g].words = append(groups[g].words, word)
+			continue
+		}
+		position, err := strconv.Atoi(positionText)
+		if err != nil {
+			return nil, fmt.Errorf("group stream: corrupt spill line %q", scanner.Text())
+		}
+		index[key] = len(groups)
+		groups = append(groups, streamGroup{first: position, words: []string{word}})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("group stream: reading spill file: %w", err)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].first < groups[j].first
+	})
+	return groups, nil
+}
+
+// writeGroups writes one group per line, words separated by spaces
+func writeGroups(w *bufio.Writer, groups []streamGroup) error {
+	for _, g := range groups {
+		if _, err := fmt.Fprintln(w, strings.Join(g.words, " ")); err != nil {
+			return fmt.Errorf("group stream: writing output: %w", err)
+		}
+	}
+	return nil
+}
+
+// GroupOrder selects how groupAnagramsOrdered arranges the groups
+// Members of a group are always in input order
+type GroupOrder int
+
+const (
+	// FirstAppearance orders groups by the position of their first word (the default)
+	FirstAppearance GroupOrder = iota
+	// BySize puts the largest groups first, ties in order of first appearance
+	BySize
+	// ByKey sorts groups by their canonical key, the group's letters in sorted order
+	ByKey
+)
+
+// groupAnagramsOrdered groups like groupAnagramsUnicode and then arranges the groups
+// Time Complexity: O(n * k log k + g log g) for g groups
+func groupAnagramsOrdered(strs []string, order GroupOrder, foldCase bool) [][]string {
+	result := groupAnagramsUnicode(strs, foldCase)
+
+	switch order {
+	case BySize:
+		sort.SliceStable(result, func(i, j int) bool {
+			return len(result[i]) > len(result[j])
+		})
+	case ByKey:
+		keys := make(map[string]string, len(result))
+		for _, anagramGroup := range result {
+			keys[anagramGroup[0]] = runeSignature(anagramGroup[0], foldCase)
+		}
+		sort.SliceStable(result, func(i, j int) bool {
+			return keys[result[i][0]] < keys[result[j][0]]
+		})
 	}
 
 	return result
 }
 
+// runeSignature returns the runes of s sorted, so all anagrams share it
+// With foldCase, every rune is first mapped to one representative of its
+// case-folding orbit from the unicode tables ('K', 'k' and the Kelvin sign all match)
+func runeSignature(s string, foldCase bool) string {
+	runes := []rune(s)
+	if foldCase {
+		for i, r := range runes {
+			runes[i] = foldRune(r)
+		}
+	}
+	slices.Sort(runes)
+	return string(runes)
+}
+
+// foldRune returns the smallest rune that is case-equivalent to r
+func foldRune(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		smallest = min(smallest, f)
+	}
+	return smallest
+}
+
+// allLowercaseASCII reports whether the [26]byte fast path can handle every word
+func allLowercaseASCII(strs []string) bool {
+	for _, str := range strs {
+		for i := 0; i < len(str); i++ {
+			if str[i] < 'a' || str[i] > 'z' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AnagramIndex answers anagram queries against a fixed dictionary
+// Built once from the groupAnagrams groups, so each query is a lookup
+// instead of regrouping the whole dictionary. Words must be lowercase a-z.
+type AnagramIndex struct {
+	groups      [][]string     // anagram groups in first-appearance order
+	bySignature map[string]int // countSignature -> position in groups
+}
+
+// NewAnagramIndex groups the dictionary and indexes every group by its signature
+// Time Complexity: O(n * k), Space Complexity: O(n * k)
+func NewAnagramIndex(words []string) (*AnagramIndex, error) {
+	for _, word := range words {
+		if !allLowercaseASCII([]string{word}) {
+			return nil, fmt.Errorf("anagram index: %q is not lowercase a-z", word)
+		}
+	}
+	groups := groupAnagrams(words)
+	for _, anagramGroup := range groups {
+		if err := checkIndexGroup(len(anagramGroup), len(anagramGroup[0])); err != nil {
+			return nil, fmt.Errorf("anagram index: anagrams of %.16q: %w", anagramGroup[0], err)
+		}
+	}
+	return newIndexFromGroups(groups), nil
+}
+
+func newIndexFromGroups(groups [][]string) *AnagramIndex {
+	index := &AnagramIndex{
+		groups:      groups,
+		bySignature: make(map[string]int, len(groups)),
+	}
+	for i, anagramGroup := range groups {
+		index.bySignature[countSignature(anagramGroup[0])] = i
+	}
+	return index
+}
+
+// Lookup returns every dictionary word that is an anagram of word (including
+// word itself if present), or nil. The slice is shared with the index.
+// Time Complexity: O(k)
+func (idx *AnagramIndex) Lookup(word string) []string {
+	if !allLowercaseASCII([]string{word}) {
+		return nil
+	}
+	if i, exists := idx.bySignature[countSignature(word)]; exists {
+		return idx.groups[i]
+	}
+	return nil
+}
+
+// FormableFrom returns the dictionary words that can be spelled with the given
+// tiles, each tile used at most once ("sub-anagrams"), in dictionary group order.
+// Small racks enumerate every sub-multiset of the tiles and look each one up;
+// when that would be more work than scanning the groups, the groups are scanned.
+// Time Complexity: O(min(sub-multisets of tiles, groups) * 26)
+func (idx *AnagramIndex) FormableFrom(tiles string) []string {
+	if !allLowercaseASCII([]string{tiles}) {
+		return nil
+	}
+	available := [26]int{}
+	for i := 0; i < len(tiles); i++ {
+		available[tiles[i]-'a']++
+	}
+
+	// Number of sub-multisets: product of (count + 1) over the letters
+	subsets := 1
+	for _, count := range available {
+		subsets *= count + 1
+		if subsets > len(idx.groups) {
+			break
+		}
+	}
+
+	var matches []int
+	if subsets <= len(idx.groups) {
+		var pick [26]int
+		var enumerate func(letter int)
+		enumerate = func(letter int) {
+			if letter == 26 {
+				if i, exists := idx.bySignature[encodeCounts(pick)]; exists {
+					matches = append(matches, i)
+				}
+				return
+			}
+			for pick[letter] = 0; pick[letter] <= available[letter]; pick[letter]++ {
+				enumerate(letter + 1)
+			}
+			pick[letter] = 0
+		}
+		enumerate(0)
+	} else {
+		for i, anagramGroup := range idx.groups {
+			if fitsIn(anagramGroup[0], available) {
+				matches = append(matches, i)
+			}
+		}
+	}
+
+	sort.Ints(matches)
+	words := []string{}
+	for _, i := range matches {
+		words = append(words, idx.groups[i]...)
+	}
+	return words
+}
+
+// fitsIn reports whether word uses no letter more often than available allows
+func fitsIn(word string, available [26]int) bool {
+	for i := 0; i < len(word); i++ {
+		available[word[i]-'a']--
+		if available[word[i]-'a'] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// anagramIndexMagic starts every serialised index (format version 1)
+const anagramIndexMagic = "ANAGIDX1"
+
+// Limits every AnagramIndex stays within. ReadAnagramIndex enforces them before
+// allocating, so a corrupt or hostile file fails with an error instead of a
+// huge allocation; NewAnagramIndex enforces them too, so any index it builds
+// can be written and loaded back.
+const (
+	maxIndexWordLength = 1 << 16 // letters in one word
+	maxIndexGroupWords = 1 << 20 // anagrams in one group
+	maxIndexGroupBytes = 1 << 26 // letters in one group, all words together
+)
+
+// checkIndexGroup reports whether a group of wordCount words of length letters
+// is within the index limits
+func checkIndexGroup(wordCount, length int) error {
+	if length > maxIndexWordLength {
+		return fmt.Errorf("word length %d exceeds the limit of %d", length, maxIndexWordLength)
+	}
+	if wordCount > maxIndexGroupWords {
+		return fmt.Errorf("word count %d exceeds the limit of %d", wordCount, maxIndexGroupWords)
+	}
+	if length > 0 && wordCount > maxIndexGroupBytes/length {
+		return fmt.Errorf("%d words of %d letters exceed the limit of %d letters",
+			wordCount, length, maxIndexGroupBytes)
+	}
+	return nil
+}
+
+// WriteTo serialises the index in a compact binary format:
+// magic, then uvarint group count, and per group a uvarint word count and a
+// uvarint word length followed by the words back to back - anagrams all have
+// the same length, so it is stored once per group instead of once per word.
+// Signatures are not stored; they are cheap to recompute and the groups are
+// already formed, so reloading never regroups the dictionary.
+func (idx *AnagramIndex) WriteTo(w io.Writer) (int64, error) {
+	buf := bufio.NewWriter(w)
+	var written int64
+	var scratch [binary.MaxVarintLen64]byte
+
+	put := func(b []byte) error {
+		n, err := buf.Write(b)
+		written += int64(n)
+		return err
+	}
+	putUvarint := func(x int) error {
+		return put(scratch[:binary.PutUvarint(scratch[:], uint64(x))])
+	}
+
+	if err := put([]byte(anagramIndexMagic)); err != nil {
+		return written, err
+	}
+	if err := putUvarint(len(idx.groups)); err != nil {
+		return written, err
+	}
+	for _, anagramGroup := range idx.groups {
+		if err := putUvarint(len(anagramGroup)); err != nil {
+			return written, err
+		}
+		if err := putUvarint(len(anagramGroup[0])); err != nil {
+			return written, err
+		}
+		for _, word := range anagramGroup {
+			if err := put([]byte(word)); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, buf.Flush()
+}
+
+// ReadAnagramIndex loads an index written by WriteTo
+// The input is untrusted: counts are checked against the limits above before
+// anything is allocated, and every group must hold anagrams of one signature
+// that no other group uses.
+// Time Complexity: O(total letters) - one signature per word, no regrouping
+func ReadAnagramIndex(r io.Reader) (*AnagramIndex, error) {
+	buf := bufio.NewReader(r)
+
+	magic := make([]byte, len(anagramIndexMagic))
+	if _, err := io.ReadFull(buf, magic); err != nil {
+		return nil, fmt.Errorf("anagram index: reading header: %w", err)
+	}
+	if string(magic) != anagramIndexMagic {
+		return nil, fmt.Errorf("anagram index: bad header %q", magic)
+	}
+
+	readCount := func(what string, limit int) (int, error) {
+		n, err := binary.ReadUvarint(buf)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("reading %s: %w", what, err)
+		}
+		if n > uint64(limit) {
+			return 0, fmt.Errorf("%s %d exceeds the limit of %d", what, n, limit)
+		}
+		return int(n), nil
+	}
+
+	groupCount, err := readCount("group count", math.MaxInt)
+	if err != nil {
+		return nil, fmt.Errorf("anagram index: %w", err)
+	}
+
+	// Grown as groups are actually read: groupCount alone proves nothing
+	index := &AnagramIndex{bySignature: make(map[string]int)}
+	for g := 0; g < groupCount; g++ {
+		wordCount, err := readCount("word count", maxIndexGroupWords)
+		if err != nil {
+			return nil, fmt.Errorf("anagram index: group %d: %w", g, err)
+		}
+		if wordCount == 0 {
+			return nil, fmt.Errorf("anagram index: group %d is empty", g)
+		}
+		length, err := readCount("word length", maxIndexWordLength)
+		if err != nil {
+			return nil, fmt.Errorf("anagram index: group %d: %w", g, err)
+		}
+		if err := checkIndexGroup(wordCount, length); err != nil {
+			return nil, fmt.Errorf("anagram index: group %d: %w", g, err)
+		}
+		letters := make([]byte, wordCount*length)
+		if _, err := io.ReadFull(buf, letters); err != nil {
+			return nil, fmt.Errorf("anagram index: group %d: %w", g, err)
+		}
+
+		anagramGroup := make([]string, wordCount)
+		for i := range anagramGroup {
+			anagramGroup[i] = string(letters[i*length : (i+1)*length])
+		}
+		if !allLowercaseASCII(anagramGroup) {
+			return nil, fmt.Errorf("anagram index: group %d is not lowercase a-z", g)
+		}
+
+		signature := countSignature(anagramGroup[0])
+		for _, word := range anagramGroup[1:] {
+			if countSignature(word) != signature {
+				return nil, fmt.Errorf("anagram index: group %d: %q is not an anagram of %q",
+					g, word, anagramGroup[0])
+			}
+		}
+		if previous, exists := index.bySignature[signature]; exists {
+			return nil, fmt.Errorf("anagram index: groups %d and %d hold the same anagrams", previous, g)
+		}
+		index.bySignature[signature] = len(index.groups)
+		index.groups = append(index.groups, anagramGroup)
+	}
+
+	return index, nil
+}
+
 func main() {
 	// Test cases
 	strs1 := []string{"eat", "tea", "tan", "ate", "nat", "bat"}
@@ -67,4 +882,266 @@ func main() {
 	strs4 := []string{"abcdefghijklmnopqrstuvwxyz", "zyxwvutsrqponmlkjihgfedcba"}
 	fmt.Printf("Input: %v\n", strs4)
 	fmt.Printf("Output: %v\n", groupAnagrams(strs4))
+
+	// Counts above 255 used to wrap (256 'a's looked like "") or saturate
+	// (300 and 400 'a's looked the same); every pair below must stay apart
+	fmt.Println("\n=== Regression: Letter Counts Beyond 255 ===")
+	a256, a300, a400 := strings.Repeat("a", 256), strings.Repeat("a", 300), strings.Repeat("a", 400)
+	collisionCases := []struct {
+		name           string
+		strs           []string
+		expectedGroups int
+	}{
+		{"256×a vs empty string", []string{a256, ""}, 2},
+		{"300×a vs 400×a", []string{a300, a400}, 2},
+		{"255×a vs 256×a", []string{strings.Repeat("a", 255), a256}, 2},
+		{"b+256×a vs b", []string{"b" + a256, "b"}, 2},
+		{"256×a+b vs b+256×a (anagrams)", []string{a256 + "b", "b" + a256}, 1},
+	}
+	for _, tc := range collisionCases {
+		fast, safe := len(groupAnagrams(tc.strs)), len(groupAnagramsSafe(tc.strs))
+		status := "✅"
+		if fast != tc.expectedGroups || safe != tc.expectedGroups {
+			status = "❌"
+		}
+		fmt.Printf("%-32s groups: %d (safe: %d), expected %d %s\n",
+			tc.name, fast, safe, tc.expectedGroups, status)
+	}
+
+	// Unicode input would panic in groupAnagrams (freq[char-'a'] out of range)
+	fmt.Println("\n=== Unicode-Aware Grouping ===")
+	strs5 := []string{"Listen", "Silent", "enlist", "café", "éfac", "Ωμέγα", "γαμέΩ", "a b", "ba ", "123", "321"}
+	fmt.Printf("Input: %v\n", strs5)
+	fmt.Printf("Case-sensitive: %v\n", groupAnagramsUnicode(strs5, false))
+	fmt.Printf("Case-folded:    %v\n", groupAnagramsUnicode(strs5, true))
+
+	// Pure lowercase ASCII takes the [26]byte fast path
+	fmt.Printf("Fast path:      %v\n", groupAnagramsUnicode(strs1, true))
+
+	fmt.Println("\n=== Deterministic Group Ordering ===")
+	strs6 := []string{"tan", "bat", "eat", "tea", "nat", "ate", "tab", "ant"}
+	orderCases := []struct {
+		name     string
+		order    GroupOrder
+		expected string
+	}{
+		{"First appearance (default)", FirstAppearance, "[[tan nat ant] [bat tab] [eat tea ate]]"},
+		{"By size", BySize, "[[tan nat ant] [eat tea ate] [bat tab]]"},
+		{"By canonical key", ByKey, "[[bat tab] [eat tea ate] [tan nat ant]]"}, // abt < aet < ant
+	}
+	fmt.Printf("Input: %v\n", strs6)
+	for _, tc := range orderCases {
+		result := fmt.Sprint(groupAnagramsOrdered(strs6, tc.order, false))
+		status := "✅"
+		if result != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("%-27s %s %s\n", tc.name+":", result, status)
+	}
+
+	// Same input, same output on every run - no map iteration order leaks through
+	stable := true
+	for run := 0; run < 100; run++ {
+		if fmt.Sprint(groupAnagrams(strs6)) != orderCases[0].expected {
+			stable = false
+		}
+	}
+	fmt.Printf("groupAnagrams identical over 100 runs: %v\n", stable)
+
+	fmt.Println("\n=== Anagram Index: Build Once, Query Many Times ===")
+	dictionary := []string{"eat", "tea", "ate", "tan", "nat", "bat", "tab", "at", "ta", "a", "tenant", "beat"}
+	index, err := NewAnagramIndex(dictionary)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Dictionary: %v\n", dictionary)
+
+	lookupCases := []struct {
+		word     string
+		expected string
+	}{
+		{"aet", "[eat tea ate]"},
+		{"tab", "[bat tab]"},
+		{"xyz", "[]"},
+		{"Eat", "[]"}, // not lowercase a-z
+	}
+	for _, tc := range lookupCases {
+		result := fmt.Sprint(index.Lookup(tc.word))
+		status := "✅"
+		if result != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("Lookup(%q) = %s %s\n", tc.word, result, status)
+	}
+
+	formableCases := []struct {
+		tiles    string
+		expected string
+	}{
+		{"tae", "[eat tea ate at ta a]"},
+		{"tabe", "[eat tea ate bat tab at ta a beat]"},
+		{"zz", "[]"},
+		{strings.Repeat("abent", 3), "[eat tea ate tan nat bat tab at ta a tenant beat]"}, // scans groups
+	}
+	for _, tc := range formableCases {
+		result := fmt.Sprint(index.FormableFrom(tc.tiles))
+		status := "✅"
+		if result != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("FormableFrom(%q) = %s %s\n", tc.tiles, result, status)
+	}
+
+	var disk bytes.Buffer
+	size, err := index.WriteTo(&disk)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	reloaded, err := ReadAnagramIndex(&disk)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	rawSize := 0
+	for _, word := range dictionary {
+		rawSize += len(word) + 1 // one word per line
+	}
+	sameGroups := fmt.Sprint(reloaded.groups) == fmt.Sprint(index.groups)
+	fmt.Printf("Serialised: %d bytes (word list: %d bytes), reload matches: %v\n", size, rawSize, sameGroups)
+	fmt.Printf("Reloaded Lookup(\"tna\") = %v\n", reloaded.Lookup("tna"))
+
+	// header builds a serialised index by hand from the magic and raw uvarints
+	header := func(counts ...uint64) []byte {
+		raw := []byte(anagramIndexMagic)
+		for _, count := range counts {
+			raw = binary.AppendUvarint(raw, count)
+		}
+		return raw
+	}
+	corruptCases := []struct {
+		name string
+		raw  []byte
+	}{
+		{"bad header", []byte("not an index")},
+		{"group count 2^63", header(1 << 63)},
+		{"group count 2^40, no groups", header(1 << 40)},
+		{"word count 2^62", header(1, 1<<62, 3)},
+		{"word length 2^40", header(1, 1, 1<<40)},
+		{"2^20 words of 2^16 letters", header(1, 1<<20, 1<<16)},
+		{"truncated letters", append(header(1, 2, 3), "eatte"...)},
+		{"group of non-anagrams", append(header(1, 2, 3), "eattan"...)},
+		{"same group twice", append(header(2, 1, 3), "eat\x01\x03tea"...)}, // 2nd group: 1 word, 3 letters
+	}
+	for _, tc := range corruptCases {
+		_, err := ReadAnagramIndex(bytes.NewReader(tc.raw))
+		status := "✅"
+		if err == nil {
+			status = "❌"
+		}
+		fmt.Printf("Corrupt input (%s) rejected: %v %s\n", tc.name, err, status)
+	}
+
+	// The same limits apply when building, so no index is saved that cannot be loaded
+	for _, oversized := range [][]string{
+		{strings.Repeat("a", 70000)},
+		slices.Repeat([]string{"ab", "ba"}, 1<<19+1),
+	} {
+		_, err := NewAnagramIndex(oversized)
+		status := "✅"
+		if err == nil {
+			status = "❌"
+		}
+		fmt.Printf("Oversized dictionary rejected: %v %s\n", err, status)
+	}
+
+	fmt.Println("\n=== Parallel Sharded Grouping on a Generated Word List ===")
+	// Shuffled copies of a few thousand base words give realistic anagram groups
+	rng := rand.New(rand.NewSource(1))
+	bases := make([][]byte, 5000)
+	for i := range bases {
+		bases[i] = make([]byte, 3+rng.Intn(6))
+		for j := range bases[i] {
+			bases[i][j] = byte('a' + rng.Intn(26))
+		}
+	}
+	words := make([]string, 2_000_000)
+	for i := range words {
+		word := slices.Clone(bases[rng.Intn(len(bases))])
+		rng.Shuffle(len(word), func(a, b int) { word[a], word[b] = word[b], word[a] })
+		words[i] = string(word)
+	}
+
+	start := time.Now()
+	single := groupAnagrams(words)
+	singleTime := time.Since(start)
+	fmt.Printf("groupAnagrams (%d words):     %d groups in %v\n", len(words), len(single), singleTime)
+
+	for _, workers := range []int{2, 4, 8} {
+		start = time.Now()
+		parallel := groupAnagramsParallel(words, workers)
+		parallelTime := time.Since(start)
+
+		status := "✅"
+		if !slices.EqualFunc(single, parallel, slices.Equal[[]string]) {
+			status = "❌"
+		}
+		fmt.Printf("groupAnagramsParallel(%d workers): %d groups in %v (%.1fx) %s\n",
+			workers, len(parallel), parallelTime, float64(singleTime)/float64(parallelTime), status)
+	}
+	fmt.Printf("Speedup is bounded by GOMAXPROCS = %d\n", runtime.GOMAXPROCS(0))
+
+	fmt.Println("\n=== Streaming from a Word List with Spill-to-Disk ===")
+	wordList := "eat tea tan\nate nat bat\ntab ant listen silent enlist tinsel\nstone notes onset tones\n"
+	for _, budget := range []int{1 << 20, 64} {
+		var output strings.Builder
+		if err := groupAnagramsStream(strings.NewReader(wordList), &output, budget, ""); err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+
+		// Same groups as groupAnagrams, though spilled output is ordered per partition
+		var expected []string
+		for _, anagramGroup := range groupAnagrams(strings.Fields(wordList)) {
+			expected = append(expected, strings.Join(anagramGroup, " "))
+		}
+		got := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+		sort.Strings(expected)
+		sort.Strings(got)
+
+		status := "✅"
+		if !slices.Equal(expected, got) {
+			status = "❌"
+		}
+		fmt.Printf("Memory budget %d bytes: %s\n%s", budget, status, output.String())
+	}
+
+	// 100k generated words plus one group bigger than the budget: the 16 spill
+	// files overflow an 8 KB budget, so they are split again, level by level
+	streamWords := append(slices.Clone(words[:100_000]), slices.Repeat([]string{"stream"}, 2000)...)
+	var streamed strings.Builder
+	start = time.Now()
+	if err := groupAnagramsStream(strings.NewReader(strings.Join(streamWords, " ")), &streamed, 8<<10, ""); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	var expectedLines []string
+	for _, anagramGroup := range groupAnagrams(streamWords) {
+		expectedLines = append(expectedLines, strings.Join(anagramGroup, " "))
+	}
+	streamedLines := strings.Split(strings.TrimSuffix(streamed.String(), "\n"), "\n")
+	sort.Strings(expectedLines)
+	sort.Strings(streamedLines)
+	status := "✅"
+	if !slices.Equal(expectedLines, streamedLines) {
+		status = "❌"
+	}
+	fmt.Printf("Memory budget 8 KB, %d words: %d groups in %v %s\n",
+		len(streamWords), len(streamedLines), time.Since(start), status)
+
+	var discard strings.Builder
+	if err := groupAnagramsStream(strings.NewReader("eat Tea"), &discard, 1<<20, ""); err != nil {
+		fmt.Println("Invalid input rejected:", err)
+	}
 }
@@ -1,9 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // OPTIMAL SOLUTION: Sort + Two Pointers
@@ -11,30 +20,8 @@ import (
 // Space Complexity: O(1) - not counting the output array
 func threeSum(nums []int) [][]int {
 	var results [][]int
-	sort.Ints(nums)
-	for i := 0; i < len(nums)-2; i++ {
-		if i > 0 && nums[i] == nums[i-1] {
-			continue //To prevent the repeat
-		}
-		target, left, right := -nums[i], i+1, len(nums)-1
-		for left < right {
-			sum := nums[left] + nums[right]
-			if sum == target {
-				results = append(results, []int{nums[i], nums[left], nums[right]})
-				left++
-				right--
-				for left < right && nums[left] == nums[left-1] {
-					left++
-				}
-				for left < right && nums[right] == nums[right+1] {
-					right--
-				}
-			} else if sum > target {
-				right--
-			} else if sum < target {
-				left++
-			}
-		}
+	for triplet := range ThreeSumSeq(nums) {
+		results = append(results, []int{triplet[0], triplet[1], triplet[2]})
 	}
 	return results
 }
@@ -85,31 +72,491 @@ func threeSumHashMap(nums []int) [][]int {
 			continue
 		}
 
-		seen := make(map[int]int)
+		// Values seen so far in nums[i+1:j] - a set, the index is never needed
+		seen := make(map[int]bool)
 		target := -nums[i]
 
 		for j := i + 1; j < len(nums); j++ {
 			needed := target - nums[j]
 
-			if idx, exists := seen[needed]; exists {
-				result = append(result, []int{nums[i], nums[idx], nums[j]})
+			if seen[needed] {
+				// needed came from an earlier sorted position, so needed <= nums[j]
+				// and the triplet is already in ascending order
+				result = append(result, []int{nums[i], needed, nums[j]})
 
-				// Skip duplicates for second and third elements
-				for j+1 < len(nums) && nums[j] == nums[j+1] {
+				// Every later copy of nums[j] would meet the same needed value and
+				// repeat this triplet, so jump to the last copy of the run
+				for j+1 < len(nums) && nums[j+1] == nums[j] {
 					j++
 				}
 			}
 
-			// Add current element to seen map
-			if _, exists := seen[nums[j]]; !exists {
-				seen[nums[j]] = j
-			}
+			// Record nums[j] only after the lookup so it never pairs with itself
+			seen[nums[j]] = true
 		}
 	}
 
 	return result
 }
 
+// threeSumProperties checks a result against the brute-force oracle and
+// returns a description of the first violated property, or "" if all hold:
+// same set of triplets, each sorted, each summing to zero, no repeats
+func threeSumProperties(nums []int, result [][]int) string {
+	expected := threeSumBruteForce(slices.Clone(nums))
+
+	seen := make(map[string]bool)
+	for _, triplet := range result {
+		if len(triplet) != 3 || triplet[0]+triplet[1]+triplet[2] != 0 {
+			return fmt.Sprintf("%v does not sum to zero", triplet)
+		}
+		if !sort.IntsAreSorted(triplet) {
+			return fmt.Sprintf("%v is not sorted", triplet)
+		}
+		key := fmt.Sprint(triplet)
+		if seen[key] {
+			return fmt.Sprintf("%v reported twice", triplet)
+		}
+		seen[key] = true
+	}
+	if !sameTuples(result, expected) {
+		return fmt.Sprintf("got %v, brute force %v", result, expected)
+	}
+	return ""
+}
+
+// 3Sum Closest - Sort + Two Pointers, tracking the nearest sum instead of an exact match
+// Returns the sum of the triplet closest to target, ok is false when nums has
+// fewer than 3 elements and there is no triplet at all
+// Time Complexity: O(n²), Space Complexity: O(1)
+func ThreeSumClosest(nums []int, target int) (closest int, ok bool) {
+	if len(nums) < 3 {
+		return 0, false
+	}
+	sort.Ints(nums)
+	closest = nums[0] + nums[1] + nums[2]
+	for i := 0; i < len(nums)-2; i++ {
+		if i > 0 && nums[i] == nums[i-1] {
+			continue
+		}
+		left, right := i+1, len(nums)-1
+		for left < right {
+			sum := nums[i] + nums[left] + nums[right]
+			if abs(sum-target) < abs(closest-target) {
+				closest = sum
+			}
+			if sum == target {
+				return sum, true
+			} else if sum > target {
+				right--
+			} else {
+				left++
+			}
+		}
+	}
+	return closest, true
+}
+
+// 3Sum Smaller - count index triplets i < j < k with sum < target
+// Once nums[i] + nums[left] + nums[right] < target, every index between left
+// and right works too, so right-left triplets are counted in one step.
+// Time Complexity: O(n²), Space Complexity: O(1)
+func ThreeSumSmaller(nums []int, target int) int {
+	sort.Ints(nums)
+	count := 0
+	for i := 0; i < len(nums)-2; i++ {
+		left, right := i+1, len(nums)-1
+		for left < right {
+			if nums[i]+nums[left]+nums[right] < target {
+				count += right - left
+				left++
+			} else {
+				right--
+			}
+		}
+	}
+	return count
+}
+
+// 3Sum With Multiplicity - count index triplets i < j < k summing to target, mod 1e9+7
+// Repeated values are counted combinatorially: a run of equal values on each
+// side multiplies, and a single run holding both sides contributes C(run, 2).
+// Time Complexity: O(n²), Space Complexity: O(1)
+func ThreeSumMulti(nums []int, target int) int {
+	const mod = 1_000_000_007
+	sort.Ints(nums)
+	count := 0
+	for i := 0; i < len(nums)-2; i++ {
+		left, right := i+1, len(nums)-1
+		for left < right {
+			sum := nums[i] + nums[left] + nums[right]
+			if sum < target {
+				left++
+			} else if sum > target {
+				right--
+			} else if nums[left] == nums[right] {
+				// Everything in [left, right] is the same value: choose any two
+				run := right - left + 1
+				count = (count + run*(run-1)/2) % mod
+				break
+			} else {
+				leftRun, rightRun := 1, 1
+				for left+1 < right && nums[left+1] == nums[left] {
+					left++
+					leftRun++
+				}
+				for right-1 > left && nums[right-1] == nums[right] {
+					right--
+					rightRun++
+				}
+				count = (count + leftRun*rightRun) % mod
+				left++
+				right--
+			}
+		}
+	}
+	return count
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Brute-force references for the variants above: try every index triplet
+// Time Complexity: O(n³) - only for validating on small inputs
+func threeSumVariantsBruteForce(nums []int, target int) (closest, smaller, multi int) {
+	first := true
+	for i := 0; i < len(nums); i++ {
+		for j := i + 1; j < len(nums); j++ {
+			for k := j + 1; k < len(nums); k++ {
+				sum := nums[i] + nums[j] + nums[k]
+				if first || abs(sum-target) < abs(closest-target) {
+					closest, first = sum, false
+				}
+				if sum < target {
+					smaller++
+				}
+				if sum == target {
+					multi++
+				}
+			}
+		}
+	}
+	return closest, smaller, multi
+}
+
+// STREAMING: Sort + Two Pointers yielding triplets lazily
+// Same search as threeSum, but each triplet is handed to the caller as a
+// fixed-size [3]int instead of being appended to a [][]int, so nothing is
+// allocated per triplet and the caller can stop early by breaking out of
+// the range loop. nums is sorted in place when iteration starts.
+// Time Complexity: O(n²) for a full iteration, Space Complexity: O(1)
+func ThreeSumSeq(nums []int) iter.Seq[[3]int] {
+	return func(yield func([3]int) bool) {
+		sort.Ints(nums)
+		for i := 0; i < len(nums)-2; i++ {
+			if i > 0 && nums[i] == nums[i-1] {
+				continue //To prevent the repeat
+			}
+			if !tripletsFrom(nums, i, yield) {
+				return
+			}
+		}
+	}
+}
+
+// tripletsFrom is the two-pointer search shared by every Sort + Two Pointers
+// variant: it yields each unique triplet whose first element is nums[i]
+// (nums sorted) and returns false as soon as yield asks to stop
+func tripletsFrom(nums []int, i int, yield func([3]int) bool) bool {
+	target, left, right := -nums[i], i+1, len(nums)-1
+	for left < right {
+		sum := nums[left] + nums[right]
+		if sum == target {
+			if !yield([3]int{nums[i], nums[left], nums[right]}) {
+				return false
+			}
+			left++
+			right--
+			for left < right && nums[left] == nums[left-1] {
+				left++
+			}
+			for left < right && nums[right] == nums[right+1] {
+				right--
+			}
+		} else if sum > target {
+			right--
+		} else {
+			left++
+		}
+	}
+	return true
+}
+
+// threeSumArrays collects ThreeSumSeq into one slice of [3]int
+// A single backing array instead of one small slice per triplet
+func threeSumArrays(nums []int) [][3]int {
+	return slices.Collect(ThreeSumSeq(nums))
+}
+
+// PARALLEL: Sort + Two Pointers with the outer loop spread across goroutines
+// After sorting, each fixed first element is an independent two-pointer search.
+// Workers claim outer indices one at a time (early indices have longer inner
+// ranges, so fixed chunks would be unbalanced) and store triplets per index,
+// which are concatenated in index order to match threeSum's output exactly.
+// Returns ctx.Err() if the context is cancelled before all indices are done.
+// Time Complexity: O(n²/workers) wall clock, Space Complexity: O(n) - plus output
+func ThreeSumParallel(ctx context.Context, nums []int, workers int) ([][]int, error) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	sort.Ints(nums)
+
+	outer := len(nums) - 2
+	if outer < 1 {
+		return nil, ctx.Err()
+	}
+	perIndex := make([][][]int, outer)
+	var next atomic.Int64
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= outer {
+					return
+				}
+				if i > 0 && nums[i] == nums[i-1] {
+					continue //To prevent the repeat
+				}
+				perIndex[i] = twoSumTriplets(nums, i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var results [][]int
+	for _, triplets := range perIndex {
+		results = append(results, triplets...)
+	}
+	return results, nil
+}
+
+// twoSumTriplets collects tripletsFrom for a fixed first index i
+func twoSumTriplets(nums []int, i int) [][]int {
+	var triplets [][]int
+	tripletsFrom(nums, i, func(triplet [3]int) bool {
+		triplets = append(triplets, []int{triplet[0], triplet[1], triplet[2]})
+		return true
+	})
+	return triplets
+}
+
+// GENERALISATION: k-Sum (4Sum, 5Sum, ...) with an arbitrary target
+// Fix one element at a time and recurse until two elements remain, then use
+// the same two-pointer sweep as threeSum. Duplicates are skipped at every level.
+// Sorts nums in place, like threeSum.
+// Time Complexity: O(n^(k-1)) for k >= 2 - plus O(n log n) for sorting
+// Space Complexity: O(k) recursion depth - not counting the output
+func KSum(nums []int, k int, target int) [][]int {
+	results := [][]int{}
+	if k < 1 || len(nums) < k {
+		return results
+	}
+	sort.Ints(nums)
+	kSumFrom(nums, 0, k, target, make([]int, 0, k), &results)
+	return results
+}
+
+// kSumFrom appends to results every unique k-tuple from nums[start:] summing to
+// target, each prefixed with the elements already fixed by the outer levels
+func kSumFrom(nums []int, start, k, target int, prefix []int, results *[][]int) {
+	if k == 1 {
+		// Only reachable when KSum is called with k == 1
+		for i := start; i < len(nums); i++ {
+			if nums[i] == target {
+				*results = append(*results, append(slices.Clone(prefix), target))
+				return
+			}
+		}
+		return
+	}
+
+	if k == 2 {
+		// Base case: the two-pointer sweep from threeSum
+		left, right := start, len(nums)-1
+		for left < right {
+			sum := nums[left] + nums[right]
+			if sum == target {
+				tuple := append(slices.Clone(prefix), nums[left], nums[right])
+				*results = append(*results, tuple)
+				left++
+				right--
+				for left < right && nums[left] == nums[left-1] {
+					left++
+				}
+				for left < right && nums[right] == nums[right+1] {
+					right--
+				}
+			} else if sum > target {
+				right--
+			} else {
+				left++
+			}
+		}
+		return
+	}
+
+	for i := start; i <= len(nums)-k; i++ {
+		if i > start && nums[i] == nums[i-1] {
+			continue // To prevent the repeat, same as threeSum
+		}
+		kSumFrom(nums, i+1, k-1, target-nums[i], append(prefix, nums[i]), results)
+	}
+}
+
+// kSumBruteForce is the reference oracle: try every combination of k indices
+// Time Complexity: O(n^k) - only for validating KSum on small inputs
+func kSumBruteForce(nums []int, k int, target int) [][]int {
+	ans := [][]int{}
+	seen := make(map[string]bool)
+	chosen := make([]int, 0, k)
+
+	var choose func(start, sum int)
+	choose = func(start, sum int) {
+		if len(chosen) == k {
+			if sum == target {
+				tuple := slices.Clone(chosen)
+				sort.Ints(tuple) // Sort to handle duplicates
+				key := fmt.Sprint(tuple)
+				if !seen[key] {
+					seen[key] = true
+					ans = append(ans, tuple)
+				}
+			}
+			return
+		}
+		for i := start; i < len(nums); i++ {
+			chosen = append(chosen, nums[i])
+			choose(i+1, sum+nums[i])
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	choose(0, 0)
+	return ans
+}
+
+// sameTuples reports whether two result sets contain the same tuples, in any order
+func sameTuples(a, b [][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := make(map[string]int)
+	for _, tuple := range a {
+		keys[fmt.Sprint(tuple)]++
+	}
+	for _, tuple := range b {
+		key := fmt.Sprint(tuple)
+		if keys[key] == 0 {
+			return false
+		}
+		keys[key]--
+	}
+	return true
+}
+
+// Integer is any built-in integer type, signed or unsigned
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// addChecked returns a + b and whether it fit in T without wrapping
+// Adding a positive b can only wrap below a, adding a negative b only above a.
+func addChecked[T Integer](a, b T) (T, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return sum, false
+	}
+	return sum, true
+}
+
+// sumSign returns -1, 0 or +1: the sign of a + b + c as if computed with
+// unlimited precision. Each wrap is undone by the direction it happened in.
+func sumSign[T Integer](a, b, c T) int {
+	bc, ok := addChecked(b, c)
+	if ok {
+		abc, ok := addChecked(a, bc)
+		if ok {
+			return sign(abc)
+		}
+		return sign(bc) // a + bc wrapped past the end bc points to
+	}
+
+	wrap := 1 // b + c wrapped in the direction of c
+	if c < 0 {
+		wrap = -1
+	}
+	abc, ok := addChecked(a, bc)
+	if ok || sign(bc) == wrap {
+		return wrap // a is too small to bring the sum back into range
+	}
+	return sign(abc) // the two wraps went opposite ways and cancel out
+}
+
+func sign[T Integer](x T) int {
+	if x > 0 {
+		return 1
+	} else if x < 0 {
+		return -1
+	}
+	return 0
+}
+
+// OVERFLOW-SAFE: Sort + Two Pointers for any integer type
+// Same algorithm as threeSum, but the sum of each candidate triplet is checked
+// for wraparound, so triplets are exact even for values near the type limits.
+// Time Complexity: O(n²), Space Complexity: O(1) - not counting the output
+func ThreeSumChecked[T Integer](nums []T) [][]T {
+	var results [][]T
+	slices.Sort(nums)
+	for i := 0; i < len(nums)-2; i++ {
+		if i > 0 && nums[i] == nums[i-1] {
+			continue
+		}
+		left, right := i+1, len(nums)-1
+		for left < right {
+			switch sumSign(nums[i], nums[left], nums[right]) {
+			case 0:
+				results = append(results, []T{nums[i], nums[left], nums[right]})
+				left++
+				right--
+				for left < right && nums[left] == nums[left-1] {
+					left++
+				}
+				for left < right && nums[right] == nums[right+1] {
+					right--
+				}
+			case 1:
+				right--
+			default:
+				left++
+			}
+		}
+	}
+	return results
+}
+
 // Helper function to demonstrate the optimal algorithm step by step
 func threeSumWithVisualization(nums []int) [][]int {
 	fmt.Printf("Finding all triplets that sum to 0 in: %v\n", nums)
@@ -240,8 +687,8 @@ func main() {
 		fmt.Printf("Brute Force:  %v\n", result2)
 		fmt.Printf("HashMap:      %v\n", result3)
 
-		// Simple verification (order might differ)
-		fmt.Printf("Results match: %v\n", len(result1) == len(result2) && len(result2) == len(result3))
+		// Compare as sets of triplets (order might differ)
+		fmt.Printf("Results match: %v\n", sameTuples(result1, result2) && sameTuples(result2, result3))
 	}
 
 	fmt.Println("\n=== Algorithm Visualization ===")
@@ -249,6 +696,225 @@ func main() {
 	visualNums := []int{-1, 0, 1, 2, -1, -4}
 	threeSumWithVisualization(visualNums)
 
+	fmt.Println("\n=== k-Sum: Generalising 3Sum to Any k and Target ===")
+	fmt.Printf("4Sum([1 0 -1 0 -2 2], 0) = %v\n", KSum([]int{1, 0, -1, 0, -2, 2}, 4, 0))
+	fmt.Printf("4Sum([2 2 2 2 2], 8) = %v\n", KSum([]int{2, 2, 2, 2, 2}, 4, 8))
+	fmt.Printf("3Sum via KSum([-1 0 1 2 -1 -4], 3, 0) = %v\n", KSum([]int{-1, 0, 1, 2, -1, -4}, 3, 0))
+
+	// Random inputs with heavy duplicates, checked against the brute-force oracle
+	rng := rand.New(rand.NewSource(7))
+	for k := 2; k <= 5; k++ {
+		mismatches := 0
+		for trial := 0; trial < 300; trial++ {
+			nums := make([]int, rng.Intn(10))
+			for i := range nums {
+				nums[i] = rng.Intn(9) - 4
+			}
+			target := rng.Intn(9) - 4
+
+			expected := kSumBruteForce(nums, k, target)
+			if !sameTuples(KSum(nums, k, target), expected) {
+				mismatches++
+			}
+		}
+		status := "✅"
+		if mismatches > 0 {
+			status = "❌"
+		}
+		fmt.Printf("k=%d: 300 random inputs vs brute force, mismatches: %d %s\n", k, mismatches, status)
+	}
+
+	fmt.Println("\n=== 3Sum Closest, 3Sum Smaller and 3Sum With Multiplicity ===")
+	closest, ok := ThreeSumClosest([]int{-1, 2, 1, -4}, 1)
+	fmt.Printf("ThreeSumClosest([-1 2 1 -4], 1) = %d, %v\n", closest, ok)
+	closest, ok = ThreeSumClosest([]int{1, 2}, 3)
+	fmt.Printf("ThreeSumClosest([1 2], 3) = %d, %v (no triplet)\n", closest, ok)
+	fmt.Printf("ThreeSumSmaller([-2 0 1 3], 2) = %d\n", ThreeSumSmaller([]int{-2, 0, 1, 3}, 2))
+	fmt.Printf("ThreeSumMulti([1 1 2 2 3 3 4 4 5 5], 8) = %d\n",
+		ThreeSumMulti([]int{1, 1, 2, 2, 3, 3, 4, 4, 5, 5}, 8))
+	fmt.Printf("ThreeSumMulti([1 1 2 2 2 2], 5) = %d\n", ThreeSumMulti([]int{1, 1, 2, 2, 2, 2}, 5))
+
+	closestMismatches, smallerMismatches, multiMismatches := 0, 0, 0
+	for trial := 0; trial < 500; trial++ {
+		nums := make([]int, 3+rng.Intn(10))
+		for i := range nums {
+			nums[i] = rng.Intn(11) - 5
+		}
+		target := rng.Intn(21) - 10
+
+		closest, smaller, multi := threeSumVariantsBruteForce(nums, target)
+		// Ties can pick a different sum at the same distance, so compare distances
+		if got, ok := ThreeSumClosest(nums, target); !ok || abs(got-target) != abs(closest-target) {
+			closestMismatches++
+		}
+		if ThreeSumSmaller(nums, target) != smaller {
+			smallerMismatches++
+		}
+		if ThreeSumMulti(nums, target) != multi {
+			multiMismatches++
+		}
+	}
+	for _, check := range []struct {
+		name       string
+		mismatches int
+	}{
+		{"ThreeSumClosest", closestMismatches},
+		{"ThreeSumSmaller", smallerMismatches},
+		{"ThreeSumMulti", multiMismatches},
+	} {
+		status := "✅"
+		if check.mismatches > 0 {
+			status = "❌"
+		}
+		fmt.Printf("%s: 500 random inputs vs brute force, mismatches: %d %s\n",
+			check.name, check.mismatches, status)
+	}
+
+	fmt.Println("\n=== Property Checks Against the Brute-Force Oracle ===")
+	// Small value ranges produce long runs of duplicates, the hard case for
+	// duplicate skipping; every approach must match brute force as a set
+	approaches := []struct {
+		name string
+		run  func([]int) [][]int
+	}{
+		{"threeSum", threeSum},
+		{"threeSumHashMap", threeSumHashMap},
+		{"ThreeSumSeq", func(nums []int) [][]int {
+			var result [][]int
+			for triplet := range ThreeSumSeq(nums) {
+				result = append(result, triplet[:])
+			}
+			return result
+		}},
+	}
+	for _, approach := range approaches {
+		failures, counterexample := 0, ""
+		for trial := 0; trial < 2000; trial++ {
+			nums := make([]int, rng.Intn(16))
+			for i := range nums {
+				nums[i] = rng.Intn(2*(1+trial%4)+1) - (1 + trial%4) // ranges [-1,1] .. [-4,4]
+			}
+			if problem := threeSumProperties(nums, approach.run(slices.Clone(nums))); problem != "" {
+				if failures == 0 {
+					counterexample = fmt.Sprintf("nums = %v: %s", nums, problem)
+				}
+				failures++
+			}
+		}
+		if failures == 0 {
+			fmt.Printf("%-16s 2000 random inputs, all properties hold ✅\n", approach.name)
+		} else {
+			fmt.Printf("%-16s %d failures ❌ e.g. %s\n", approach.name, failures, counterexample)
+		}
+	}
+
+	fmt.Println("\n=== Streaming Triplets with ThreeSumSeq ===")
+	for _, tc := range testCases {
+		streamed := threeSumArrays(slices.Clone(tc.nums))
+		collected := threeSum(slices.Clone(tc.nums))
+
+		matches := len(streamed) == len(collected)
+		for i := 0; matches && i < len(streamed); i++ {
+			matches = streamed[i] == [3]int(collected[i])
+		}
+		status := "✅"
+		if !matches {
+			status = "❌"
+		}
+		fmt.Printf("%-36s %v %s\n", tc.name+":", streamed, status)
+	}
+
+	// Early termination: stop after the first few triplets without computing the rest
+	manyZeroSums := make([]int, 0, 2001)
+	for v := -1000; v <= 1000; v++ {
+		manyZeroSums = append(manyZeroSums, v)
+	}
+	fmt.Print("First 3 triplets of [-1000..1000]:")
+	taken := 0
+	for triplet := range ThreeSumSeq(manyZeroSums) {
+		fmt.Printf(" %v", triplet)
+		taken++
+		if taken == 3 {
+			break
+		}
+	}
+	fmt.Println()
+
+	total := 0
+	for range ThreeSumSeq(manyZeroSums) {
+		total++
+	}
+	fmt.Printf("Counted all %d triplets without storing any\n", total)
+
+	fmt.Println("\n=== Parallel 3Sum on a 10^5-Element Input ===")
+	// Values in [-500, 500] keep the output small enough to print a summary
+	large := make([]int, 100_000)
+	for i := range large {
+		large[i] = rng.Intn(1001) - 500
+	}
+
+	start := time.Now()
+	sequential := threeSum(slices.Clone(large))
+	sequentialTime := time.Since(start)
+	fmt.Printf("threeSum:                    %d triplets in %v\n", len(sequential), sequentialTime)
+
+	for _, workers := range []int{2, 4, 8} {
+		start = time.Now()
+		parallel, err := ThreeSumParallel(context.Background(), slices.Clone(large), workers)
+		parallelTime := time.Since(start)
+
+		status := "✅"
+		if err != nil || fmt.Sprint(parallel) != fmt.Sprint(sequential) {
+			status = "❌"
+		}
+		fmt.Printf("ThreeSumParallel(%d workers): %d triplets in %v (%.1fx) %s\n",
+			workers, len(parallel), parallelTime, float64(sequentialTime)/float64(parallelTime), status)
+	}
+	fmt.Printf("Speedup is bounded by GOMAXPROCS = %d\n", runtime.GOMAXPROCS(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	_, err := ThreeSumParallel(ctx, slices.Clone(large), 4)
+	cancel()
+	fmt.Printf("With a 1ms deadline: err = %v\n", err)
+
+	fmt.Println("\n=== Overflow-Checked 3Sum at Integer Boundaries ===")
+	// Plain threeSum wraps: MaxInt + MaxInt == -2 in int, so 2 looks like a match
+	fmt.Printf("threeSum([MaxInt MaxInt 2]) = %v ❌ expected []\n",
+		threeSum([]int{math.MaxInt, math.MaxInt, 2}))
+
+	boundaryCases := []struct {
+		name     string
+		run      func() string
+		expected string
+	}{
+		{"int: [MaxInt MaxInt 2]", func() string {
+			return fmt.Sprint(ThreeSumChecked([]int{math.MaxInt, math.MaxInt, 2}))
+		}, "[]"},
+		{"int64: [MinInt64 MinInt64 0]", func() string {
+			return fmt.Sprint(ThreeSumChecked([]int64{math.MinInt64, math.MinInt64, 0}))
+		}, "[]"},
+		{"int64: [MinInt64 2^62 2^62]", func() string {
+			return fmt.Sprint(ThreeSumChecked([]int64{math.MinInt64, 1 << 62, 1 << 62}))
+		}, "[[-9223372036854775808 4611686018427387904 4611686018427387904]]"},
+		{"int64: [MinInt64+1 -1 0 1 MaxInt64]", func() string {
+			return fmt.Sprint(ThreeSumChecked([]int64{math.MinInt64 + 1, -1, 0, 1, math.MaxInt64}))
+		}, "[[-9223372036854775807 0 9223372036854775807] [-1 0 1]]"},
+		{"uint64: [MaxUint64 1 0]", func() string {
+			return fmt.Sprint(ThreeSumChecked([]uint64{math.MaxUint64, 1, 0}))
+		}, "[]"},
+		{"uint64: [0 0 0 5]", func() string {
+			return fmt.Sprint(ThreeSumChecked([]uint64{0, 0, 0, 5}))
+		}, "[[0 0 0]]"},
+	}
+	for _, tc := range boundaryCases {
+		result := tc.run()
+		status := "✅"
+		if result != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("%-36s -> %s %s\n", tc.name, result, status)
+	}
+
 	fmt.Println("\n=== Your Original Approach Analysis ===")
 	fmt.Println("✅ WHAT YOU DID RIGHT:")
 	fmt.Println("• Correct logic with three nested loops")
@@ -290,5 +956,6 @@ func main() {
 	fmt.Println("2. ✅ Two Sum II (Two Pointers) - Sorted array optimization")
 	fmt.Println("3. ✅ 3Sum (Your approach) - Brute force understanding")
 	fmt.Println("4. 🎯 3Sum (Optimal) - Combine sorting + two pointers")
-	fmt.Println("5. 🔜 Next: 4Sum, 3Sum Closest, etc.")
+	fmt.Println("5. ✅ k-Sum - 4Sum and beyond by recursing down to two pointers")
+	fmt.Println("6. ✅ 3Sum Closest / Smaller / Multiplicity - same core, different bookkeeping")
 }
@@ -1,6 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
 
 // Two Sum - Optimized HashMap Solution
 // Time Complexity: O(n) - single pass through the array
@@ -48,6 +54,302 @@ func twoSumTwoPass(nums []int, target int) []int {
 	return []int{}
 }
 
+// FindPair is twoSum with an explicit "found" result instead of the empty-slice sentinel
+// Time Complexity: O(n), Space Complexity: O(n)
+func FindPair(nums []int, target int) (pair [2]int, ok bool) {
+	numMap := make(map[int]int)
+
+	for i, num := range nums {
+		if index, exists := numMap[target-num]; exists {
+			return [2]int{index, i}, true
+		}
+		numMap[num] = i
+	}
+
+	return [2]int{}, false
+}
+
+// AllPairs returns every index pair (i, j) with i < j and nums[i] + nums[j] == target
+// Pairs are ordered by j, then by i
+// Time Complexity: O(n + p) where p is the number of pairs returned
+// Space Complexity: O(n) for the index map (plus the output)
+func AllPairs(nums []int, target int) [][2]int {
+	// Map each value to every index where it has appeared so far
+	indices := make(map[int][]int)
+	pairs := [][2]int{}
+
+	for j, num := range nums {
+		for _, i := range indices[target-num] {
+			pairs = append(pairs, [2]int{i, j})
+		}
+		indices[num] = append(indices[num], j)
+	}
+
+	return pairs
+}
+
+// AllPairsByValue is AllPairs de-duplicated by value: each distinct
+// {smaller, larger} value combination is reported once, using the first
+// index pair at which it completes
+// Time Complexity: O(n), Space Complexity: O(n)
+func AllPairsByValue(nums []int, target int) [][2]int {
+	firstIndex := make(map[int]int)
+	reported := make(map[int]bool) // keyed by the smaller value of the pair
+	pairs := [][2]int{}
+
+	for j, num := range nums {
+		complement := target - num
+		if i, exists := firstIndex[complement]; exists {
+			smaller := min(num, complement)
+			if !reported[smaller] {
+				reported[smaller] = true
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+		if _, exists := firstIndex[num]; !exists {
+			firstIndex[num] = j
+		}
+	}
+
+	return pairs
+}
+
+// CountPairs counts index pairs (i < j) summing to target without building them
+// Repeated values are handled combinatorially: c copies of target/2 give c*(c-1)/2 pairs
+// Time Complexity: O(n), Space Complexity: O(u) for u unique values
+func CountPairs(nums []int, target int) int {
+	counts := make(map[int]int)
+	for _, num := range nums {
+		counts[num]++
+	}
+
+	total := 0
+	for num, count := range counts {
+		complement := target - num
+		if complement == num {
+			total += count * (count - 1) / 2
+		} else if num < complement {
+			// Count each value combination once, from its smaller side
+			total += count * counts[complement]
+		}
+	}
+
+	return total
+}
+
+// BatchResult is the answer for one target of a batch query
+type BatchResult struct {
+	Pair  [2]int // indices i < j, only meaningful when Found
+	Found bool
+}
+
+// pairIndex is the shared value index for batch queries: for every value it
+// keeps the first two positions, which is all a pair lookup ever needs
+type pairIndex struct {
+	first  map[int]int
+	second map[int]int
+}
+
+func newPairIndex(nums []int) pairIndex {
+	index := pairIndex{first: make(map[int]int), second: make(map[int]int)}
+	for i, num := range nums {
+		if _, exists := index.first[num]; !exists {
+			index.first[num] = i
+		} else if _, exists := index.second[num]; !exists {
+			index.second[num] = i
+		}
+	}
+	return index
+}
+
+// find answers one target against the prebuilt index (read-only, safe for goroutines)
+func (index pairIndex) find(nums []int, target int) BatchResult {
+	for i, num := range nums {
+		complement := target - num
+		j, exists := index.first[complement]
+		if exists && j == i {
+			// nums[i] is the first copy of its own complement, try the second copy
+			j, exists = index.second[complement]
+		}
+		if exists {
+			return BatchResult{Pair: [2]int{min(i, j), max(i, j)}, Found: true}
+		}
+	}
+	return BatchResult{}
+}
+
+// TwoSumBatch answers many targets over the same array, building the value index once
+// Time Complexity: O(n + n*t) for t targets, with no per-target map rebuild
+// Space Complexity: O(n) for the shared index
+func TwoSumBatch(nums []int, targets []int) map[int]BatchResult {
+	index := newPairIndex(nums)
+	results := make(map[int]BatchResult, len(targets))
+
+	for _, target := range targets {
+		if _, done := results[target]; !done {
+			results[target] = index.find(nums, target)
+		}
+	}
+
+	return results
+}
+
+// TwoSumBatchParallel is TwoSumBatch with the targets fanned out across workers
+// The index is built once and only read by the goroutines, so no locking is needed
+func TwoSumBatchParallel(nums []int, targets []int, workers int) map[int]BatchResult {
+	if workers < 1 {
+		workers = 1
+	}
+	index := newPairIndex(nums)
+
+	// Each worker fills its own slots, so the slice needs no synchronisation
+	answers := make([]BatchResult, len(targets))
+	chunk := (len(targets) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(targets); start += chunk {
+		end := min(start+chunk, len(targets))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for k := start; k < end; k++ {
+				answers[k] = index.find(nums, targets[k])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	// Key the answers back to their targets
+	results := make(map[int]BatchResult, len(targets))
+	for k, target := range targets {
+		results[target] = answers[k]
+	}
+
+	return results
+}
+
+// Integer is any built-in integer type, signed or unsigned
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// subChecked returns a - b and whether it fit in T without wrapping
+// Subtracting a positive b can only wrap above a, a negative b only below a.
+func subChecked[T Integer](a, b T) (T, bool) {
+	diff := a - b
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return diff, false
+	}
+	return diff, true
+}
+
+// TwoSumChecked is twoSum without silent wraparound, for any integer type
+// A complement that does not fit in T cannot be stored in nums, so it is
+// skipped instead of wrapping into an unrelated value and giving a false pair.
+// Time Complexity: O(n), Space Complexity: O(n)
+func TwoSumChecked[T Integer](nums []T, target T) (pair [2]int, ok bool) {
+	numMap := make(map[T]int)
+
+	for i, num := range nums {
+		complement, fits := subChecked(target, num)
+		if fits {
+			if index, exists := numMap[complement]; exists {
+				return [2]int{index, i}, true
+			}
+		}
+		numMap[num] = i
+	}
+
+	return [2]int{}, false
+}
+
+// Closest Two Sum for floating point data - Sort + Two Pointers (as in Two Sum II)
+// Returns the original indices (i < j) of the pair minimising |nums[i] + nums[j] - target|
+// Time Complexity: O(n log n) - sorting an index permutation dominates
+// Space Complexity: O(n) - the permutation, nums itself is left untouched
+func TwoSumClosest(nums []float64, target float64) (pair [2]int, ok bool) {
+	// Sort positions by value so the original indices can be reported
+	order := make([]int, 0, len(nums))
+	for i, num := range nums {
+		if !math.IsNaN(num) {
+			order = append(order, i)
+		}
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return nums[order[a]] < nums[order[b]]
+	})
+
+	bestDiff := math.Inf(1)
+	left, right := 0, len(order)-1
+	for left < right {
+		i, j := order[left], order[right]
+		sum := nums[i] + nums[j]
+
+		if diff := math.Abs(sum - target); diff < bestDiff {
+			bestDiff = diff
+			pair, ok = [2]int{min(i, j), max(i, j)}, true
+		}
+
+		if sum == target {
+			break // cannot get closer than exact
+		} else if sum < target {
+			left++
+		} else {
+			right--
+		}
+	}
+
+	return pair, ok
+}
+
+// Within-tolerance Two Sum for floating point data - HashMap bucketed by eps
+// Finds indices (i < j) with |nums[i] + nums[j] - target| <= eps in one pass.
+// Values are grouped into buckets at least eps wide, so every value within eps
+// of the complement lives in the complement's bucket or one of its neighbours.
+// Buckets are also never narrower than the rounding error of target - num, so
+// eps = 0 (exact a + b == target) works even when target - a != b in floats.
+// A partner right at the edge can land two buckets away, so two neighbours on
+// each side are checked. NaN and infinite values are skipped.
+// Time Complexity: O(n) expected, Space Complexity: O(n)
+func TwoSumWithin(nums []float64, target, eps float64) (pair [2]int, ok bool) {
+	eps = max(eps, 0)
+	finite := func(x float64) bool { return !math.IsNaN(x) && !math.IsInf(x, 0) }
+
+	// Rounding errors scale with the largest magnitude involved
+	magnitude := 0.0
+	if finite(target) {
+		magnitude = math.Abs(target)
+	}
+	for _, num := range nums {
+		if finite(num) {
+			magnitude = max(magnitude, math.Abs(num))
+		}
+	}
+	width := max(eps, magnitude*0x1p-48, math.SmallestNonzeroFloat64)
+
+	buckets := make(map[float64][]int)
+	for j, num := range nums {
+		if !finite(num) {
+			continue
+		}
+		key := math.Floor((target - num) / width)
+
+		for _, k := range []float64{key, key - 1, key + 1, key - 2, key + 2} {
+			for _, i := range buckets[k] {
+				if math.Abs(nums[i]+num-target) <= eps {
+					return [2]int{i, j}, true
+				}
+			}
+		}
+
+		numKey := math.Floor(num / width)
+		buckets[numKey] = append(buckets[numKey], j)
+	}
+
+	return [2]int{}, false
+}
+
 func main() {
 	fmt.Println("=== Optimized Two Sum Solution ===")
 	fmt.Println("Using HashMap approach: O(n) time, O(n) space")
@@ -86,4 +388,186 @@ func main() {
 	fmt.Println("=== Testing Two-Pass Approach ===")
 	result1Alt := twoSumTwoPass(nums1, target1)
 	fmt.Printf("Two-pass result for [2,7,11,15], target 9: %v\n", result1Alt)
+
+	fmt.Println("\n=== Explicit (pair, ok) Result ===")
+	for _, tc := range []struct {
+		nums   []int
+		target int
+	}{
+		{[]int{2, 7, 11, 15}, 9},
+		{[]int{1, 2, 3}, 100},
+		{[]int{}, 0},
+	} {
+		pair, ok := FindPair(tc.nums, tc.target)
+		fmt.Printf("FindPair(%v, %d) = %v, ok=%v\n", tc.nums, tc.target, pair, ok)
+	}
+
+	fmt.Println("\n=== All Pairs and Pair Counting ===")
+	pairCases := []struct {
+		nums          []int
+		target        int
+		expectedCount int
+	}{
+		{[]int{1, 5, 7, -1, 5}, 6, 3},
+		{[]int{3, 3, 3, 3}, 6, 6},
+		{[]int{1, 2, 3, 4, 5}, 6, 2},
+		{[]int{1, 2, 3}, 100, 0},
+	}
+	for _, tc := range pairCases {
+		all := AllPairs(tc.nums, tc.target)
+		byValue := AllPairsByValue(tc.nums, tc.target)
+		count := CountPairs(tc.nums, tc.target)
+
+		fmt.Printf("nums = %v, target = %d\n", tc.nums, tc.target)
+		fmt.Printf("  AllPairs:        %v\n", all)
+		fmt.Printf("  AllPairsByValue: %v\n", byValue)
+		fmt.Printf("  CountPairs:      %d\n", count)
+
+		if count == tc.expectedCount && len(all) == tc.expectedCount {
+			fmt.Println("  ✅ Count matches enumeration")
+		} else {
+			fmt.Printf("  ❌ Expected %d pairs\n", tc.expectedCount)
+		}
+	}
+
+	fmt.Println("\n=== Batch Queries With One Shared Index ===")
+	batchNums := []int{4, 9, 1, 7, 3, 3}
+	batchTargets := []int{6, 10, 13, 2, 16, 100}
+	sequential := TwoSumBatch(batchNums, batchTargets)
+	parallel := TwoSumBatchParallel(batchNums, batchTargets, 3)
+
+	fmt.Printf("nums = %v\n", batchNums)
+	for _, target := range batchTargets {
+		res := sequential[target]
+		_, single := FindPair(batchNums, target)
+		status := "✅"
+		if res.Found != single || parallel[target] != res {
+			status = "❌"
+		}
+		if res.Found {
+			fmt.Printf("target %3d: found %v (%d + %d) %s\n",
+				target, res.Pair, batchNums[res.Pair[0]], batchNums[res.Pair[1]], status)
+		} else {
+			fmt.Printf("target %3d: no pair %s\n", target, status)
+		}
+	}
+
+	fmt.Println("\n=== Overflow-Checked Two Sum at Integer Boundaries ===")
+	// Plain twoSum computes target - num in int, which wraps near the limits
+	wrapNums := []int{math.MaxInt, 1, -1}
+	fmt.Printf("twoSum(%v, MinInt) = %v ❌ wrapped: MaxInt + 1 is not MinInt\n",
+		wrapNums, twoSum(wrapNums, math.MinInt))
+
+	boundaryCases := []struct {
+		name     string
+		run      func() ([2]int, bool)
+		expected bool
+	}{
+		{"int: [MaxInt 1 -1], target MinInt", func() ([2]int, bool) {
+			return TwoSumChecked([]int{math.MaxInt, 1, -1}, math.MinInt)
+		}, false},
+		{"int64: [MaxInt64 MinInt64], target -1", func() ([2]int, bool) {
+			return TwoSumChecked([]int64{math.MaxInt64, math.MinInt64}, -1)
+		}, true},
+		{"int64: [MinInt64 -1 0], target MinInt64", func() ([2]int, bool) {
+			return TwoSumChecked([]int64{math.MinInt64, -1, 0}, math.MinInt64)
+		}, true},
+		{"int64: [MinInt64 -1], target MaxInt64", func() ([2]int, bool) {
+			return TwoSumChecked([]int64{math.MinInt64, -1}, math.MaxInt64)
+		}, false},
+		{"uint64: [MaxUint64 2], target 1", func() ([2]int, bool) {
+			return TwoSumChecked([]uint64{math.MaxUint64, 2}, 1)
+		}, false},
+		{"uint64: [MaxUint64 0 5], target MaxUint64", func() ([2]int, bool) {
+			return TwoSumChecked([]uint64{math.MaxUint64, 0, 5}, math.MaxUint64)
+		}, true},
+	}
+	for _, tc := range boundaryCases {
+		pair, ok := tc.run()
+		status := "✅"
+		if ok != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("%-42s -> %v, ok=%v %s\n", tc.name, pair, ok, status)
+	}
+
+	fmt.Println("\n=== Floating Point: Closest Pair and Within Tolerance ===")
+	ledger := []float64{19.99, 5.01, 0.1, 14.98, 0.2, 9.75}
+	closestCases := []struct {
+		target   float64
+		expected [2]int
+	}{
+		{25.0, [2]int{0, 1}}, // 19.99 + 5.01 = 25.00 (up to rounding)
+		{0.3, [2]int{2, 4}},  // 0.1 + 0.2 != 0.3 exactly, but is closest
+		{30.0, [2]int{0, 5}}, // 19.99 + 9.75 = 29.74
+		{-5.0, [2]int{2, 4}}, // everything is too large, smallest sum wins
+	}
+	fmt.Printf("ledger = %v\n", ledger)
+	for _, tc := range closestCases {
+		pair, ok := TwoSumClosest(ledger, tc.target)
+		status := "✅"
+		if !ok || pair != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("TwoSumClosest(target %.2f) = %v (sum %.4f) %s\n",
+			tc.target, pair, ledger[pair[0]]+ledger[pair[1]], status)
+	}
+
+	withinCases := []struct {
+		target, eps float64
+		expected    bool
+	}{
+		{0.3, 1e-9, true},   // 0.1 + 0.2 = 0.30000000000000004
+		{0.3, 0, false},     // exact equality fails on floating point
+		{24.74, 0.01, true}, // 14.98 + 9.75 = 24.73
+		{24.74, 0.001, false},
+		{100, 1, false},
+	}
+	for _, tc := range withinCases {
+		pair, ok := TwoSumWithin(ledger, tc.target, tc.eps)
+		status := "✅"
+		if ok != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("TwoSumWithin(target %.2f, eps %g) = %v, ok=%v %s\n",
+			tc.target, tc.eps, pair, ok, status)
+	}
+	pair, ok := TwoSumWithin([]float64{1, 2}, 3, 0)
+	status := "✅"
+	if !ok || pair != [2]int{0, 1} {
+		status = "❌"
+	}
+	fmt.Printf("TwoSumWithin([1 2], target 3, eps 0) = %v, ok=%v %s\n", pair, ok, status)
+
+	// Brute force reference: does any pair satisfy |a + b - target| <= eps?
+	withinBruteForce := func(nums []float64, target, eps float64) bool {
+		for i := range nums {
+			for j := i + 1; j < len(nums); j++ {
+				if math.Abs(nums[i]+nums[j]-target) <= max(eps, 0) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	rng := rand.New(rand.NewSource(1))
+	cents := func() float64 { return float64(rng.Intn(2001)-1000) / 100 } // -10.00 .. 10.00
+	withinMismatches := 0
+	for trial := 0; trial < 100000; trial++ {
+		nums := make([]float64, 2+rng.Intn(6))
+		for i := range nums {
+			nums[i] = cents()
+		}
+		target, eps := cents()+cents(), []float64{0, 0.01, 0.18, 0.5}[rng.Intn(4)]
+		pair, ok := TwoSumWithin(nums, target, eps)
+		valid := !ok || (pair[0] < pair[1] && math.Abs(nums[pair[0]]+nums[pair[1]]-target) <= eps)
+		if ok != withinBruteForce(nums, target, eps) || !valid {
+			withinMismatches++
+		}
+	}
+	status = "✅"
+	if withinMismatches > 0 {
+		status = "❌"
+	}
+	fmt.Printf("TwoSumWithin: 100000 random inputs vs brute force, mismatches: %d %s\n", withinMismatches, status)
 }
@@ -1,6 +1,14 @@
 package main
 
-import "fmt"
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
 
 // Two Sum II - Input Array Is Sorted
 // OPTIMAL SOLUTION: Two Pointers Approach
@@ -52,6 +60,158 @@ func twoSumBinarySearch(numbers []int, target int) []int {
 	return []int{}
 }
 
+// Adaptive solution: Two Pointers with Galloping (exponential + binary search)
+// Instead of moving a pointer one step at a time, jump 1, 2, 4, ... positions
+// until overshooting, then binary search the last jump. Skewed inputs where the
+// answer sits at one extreme take O(log n) instead of O(n).
+// Time Complexity: O(log n) on skewed inputs, O(n) worst case (small constant factor)
+// Space Complexity: O(1)
+func twoSumGalloping(numbers []int, target int) []int {
+	left, right := 0, len(numbers)-1
+
+	for left < right {
+		switch compareSum(numbers[left], numbers[right], target) {
+		case 0:
+			return []int{left + 1, right + 1}
+		case -1:
+			// Skip every left value that is still too small for numbers[right]
+			limit, ok := subChecked(target, numbers[right])
+			if !ok {
+				return []int{} // needs a partner above the largest int
+			}
+			left = gallopUp(numbers, left, right-1, limit)
+		default:
+			// Skip every right value that is still too large for numbers[left]
+			limit, ok := subChecked(target, numbers[left])
+			if !ok {
+				return []int{} // needs a partner below the smallest int
+			}
+			right = gallopDown(numbers, left+1, right, limit)
+		}
+	}
+
+	return []int{}
+}
+
+// gallopUp returns the first index in (lo, hi] with numbers[index] >= limit,
+// or hi+1 if there is none. Requires numbers[lo] < limit.
+func gallopUp(numbers []int, lo, hi, limit int) int {
+	step := 1
+	for lo+step <= hi && numbers[lo+step] < limit {
+		lo += step
+		step *= 2
+	}
+
+	// The answer lies in (lo, min(hi, lo+step)]
+	left, right := lo+1, min(hi, lo+step)
+	for left <= right {
+		mid := left + (right-left)/2
+		if numbers[mid] < limit {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+	return left
+}
+
+// gallopDown returns the last index in [lo, hi) with numbers[index] <= limit,
+// or lo-1 if there is none. Requires numbers[hi] > limit.
+func gallopDown(numbers []int, lo, hi, limit int) int {
+	step := 1
+	for hi-step >= lo && numbers[hi-step] > limit {
+		hi -= step
+		step *= 2
+	}
+
+	// The answer lies in [max(lo, hi-step), hi)
+	left, right := max(lo, hi-step), hi-1
+	for left <= right {
+		mid := left + (right-left)/2
+		if numbers[mid] <= limit {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+	return right
+}
+
+// Count pairs with sum < target - Two Pointers sweep without enumeration
+// Whenever numbers[left] + numbers[right] < target, every index between left
+// and right also pairs with left below target, so they are counted at once.
+// Time Complexity: O(n), Space Complexity: O(1)
+func PairsLessThan(sorted []int, target int) int {
+	count := 0
+	left, right := 0, len(sorted)-1
+
+	for left < right {
+		if sorted[left]+sorted[right] < target {
+			count += right - left // pairs (left, left+1..right)
+			left++
+		} else {
+			right--
+		}
+	}
+
+	return count
+}
+
+// pairsAtMost counts pairs with sum <= limit, same sweep as PairsLessThan
+func pairsAtMost(sorted []int, limit int) int {
+	count := 0
+	left, right := 0, len(sorted)-1
+
+	for left < right {
+		if sorted[left]+sorted[right] <= limit {
+			count += right - left
+			left++
+		} else {
+			right--
+		}
+	}
+
+	return count
+}
+
+// CountPairsInRange counts index pairs i < j with lo <= sorted[i] + sorted[j] <= hi
+// Computed as (pairs <= hi) - (pairs < lo), so no bound is ever shifted by one
+// Time Complexity: O(n), Space Complexity: O(1)
+func CountPairsInRange(sorted []int, lo, hi int) int {
+	if lo > hi {
+		return 0
+	}
+	return pairsAtMost(sorted, hi) - PairsLessThan(sorted, lo)
+}
+
+// PairsInRange yields every pair (i, j), 1-indexed with i < j, whose sum is in
+// [lo, hi], in index order (by i, then j). For a fixed i the valid j form one
+// contiguous run, and both ends of that run only move left as i grows.
+// Time Complexity: O(n + pairs yielded), Space Complexity: O(1)
+func PairsInRange(sorted []int, lo, hi int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		n := len(sorted)
+		first, last := n, n-1 // run of valid j is [first, last]
+
+		for i := 0; i < n-1; i++ {
+			// Drop j whose sum with sorted[i] is above hi
+			for last > i && sorted[i]+sorted[last] > hi {
+				last--
+			}
+			// Extend j down while the sum still reaches lo
+			for first-1 > i && sorted[i]+sorted[first-1] >= lo {
+				first--
+			}
+
+			for j := max(first, i+1); j <= last; j++ {
+				if !yield(i+1, j+1) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // Naive solution using HashMap (like original Two Sum)
 // Time Complexity: O(n)
 // Space Complexity: O(n) - violates the constant space requirement!
@@ -69,6 +229,295 @@ func twoSumHashMap(numbers []int, target int) []int {
 	return []int{}
 }
 
+// Integer is any built-in integer type, signed or unsigned
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// addChecked returns a + b and whether it fit in T without wrapping
+// Adding a positive b can only wrap below a, adding a negative b only above a.
+func addChecked[T Integer](a, b T) (T, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return sum, false
+	}
+	return sum, true
+}
+
+// subChecked returns a - b and whether it fit in T without wrapping
+// Subtracting a positive b can only wrap above a, a negative b only below a.
+func subChecked[T Integer](a, b T) (T, bool) {
+	diff := a - b
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return diff, false
+	}
+	return diff, true
+}
+
+// compareSum compares a + b with target as if computed with unlimited precision
+// Returns -1, 0 or +1. A sum that does not fit in T is beyond every target.
+func compareSum[T Integer](a, b, target T) int {
+	sum, ok := addChecked(a, b)
+	if !ok {
+		if b > 0 {
+			return 1 // true sum is above the largest T
+		}
+		return -1 // true sum is below the smallest T
+	}
+	if sum < target {
+		return -1
+	} else if sum > target {
+		return 1
+	}
+	return 0
+}
+
+// Overflow-safe Two Pointers for any integer type
+// Same walk as twoSum, but the pointer decision uses the widened sum, so
+// values near the type limits never wrap into the wrong direction.
+// Time Complexity: O(n), Space Complexity: O(1)
+func TwoSumChecked[T Integer](numbers []T, target T) []int {
+	left, right := 0, len(numbers)-1
+
+	for left < right {
+		switch compareSum(numbers[left], numbers[right], target) {
+		case 0:
+			return []int{left + 1, right + 1}
+		case -1:
+			left++
+		default:
+			right--
+		}
+	}
+
+	return []int{}
+}
+
+// SortedSource is any ascending, random-access sequence of int64 values
+// (an in-memory slice, a memory-mapped file, a column from a columnar store...)
+type SortedSource interface {
+	Len() int
+	At(i int) int64
+}
+
+// Int64Slice adapts a sorted []int64 to SortedSource
+type Int64Slice []int64
+
+func (s Int64Slice) Len() int       { return len(s) }
+func (s Int64Slice) At(i int) int64 { return s[i] }
+
+// Int64File reads sorted little-endian int64 values straight from raw bytes,
+// e.g. the []byte returned by mmap on a file of fixed-width records
+type Int64File []byte
+
+func (f Int64File) Len() int { return len(f) / 8 }
+func (f Int64File) At(i int) int64 {
+	return int64(binary.LittleEndian.Uint64(f[i*8:]))
+}
+
+// Two Pointers over a SortedSource - same walk as twoSum, 1-indexed result
+// Sums are compared overflow-safely since stored int64s may sit near the limits
+// Time Complexity: O(n) At calls, Space Complexity: O(1)
+func TwoSumSource(src SortedSource, target int64) []int {
+	left, right := 0, src.Len()-1
+
+	for left < right {
+		switch compareSum(src.At(left), src.At(right), target) {
+		case 0:
+			return []int{left + 1, right + 1}
+		case -1:
+			left++
+		default:
+			right--
+		}
+	}
+
+	return []int{}
+}
+
+// Binary Search over a SortedSource - same search as twoSumBinarySearch
+// Time Complexity: O(n log n) At calls, Space Complexity: O(1)
+func TwoSumBinarySearchSource(src SortedSource, target int64) []int {
+	n := src.Len()
+	for i := 0; i < n-1; i++ {
+		first := src.At(i)
+		left, right := i+1, n-1
+
+		for left <= right {
+			mid := left + (right-left)/2
+			// Compare first + At(mid) with target instead of computing target - first
+			switch compareSum(first, src.At(mid), target) {
+			case 0:
+				return []int{i + 1, mid + 1}
+			case -1:
+				left = mid + 1
+			default:
+				right = mid - 1
+			}
+		}
+	}
+
+	return []int{}
+}
+
+// Iterator walks an ordered container one value at a time (like LeetCode 173's BSTIterator)
+type Iterator interface {
+	HasNext() bool
+	Next() int
+}
+
+// Two Pointers over two iterators instead of two indices
+// asc yields values smallest first, desc yields the same values largest first,
+// and n is how many values the container holds. Positions are tracked so the
+// pointers never meet, which keeps duplicates (3 + 3 = 6) working.
+// Returns the two values, since an ordered container need not have indices.
+// Time Complexity: O(n) Next calls, Space Complexity: whatever the iterators use
+func TwoSumIterators(asc, desc Iterator, n int, target int) (int, int, bool) {
+	if n < 2 || !asc.HasNext() || !desc.HasNext() {
+		return 0, 0, false
+	}
+
+	low, high := asc.Next(), desc.Next()
+	left, right := 0, n-1
+
+	for left < right {
+		currentSum := low + high
+
+		if currentSum == target {
+			return low, high, true
+		} else if currentSum < target {
+			left++
+			if left < right {
+				low = asc.Next()
+			}
+		} else {
+			right--
+			if left < right {
+				high = desc.Next()
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// sliceIterator walks a sorted slice forwards or backwards
+type sliceIterator struct {
+	numbers []int
+	pos     int
+	step    int
+}
+
+func ascendSlice(numbers []int) *sliceIterator {
+	return &sliceIterator{numbers: numbers, pos: 0, step: 1}
+}
+
+func descendSlice(numbers []int) *sliceIterator {
+	return &sliceIterator{numbers: numbers, pos: len(numbers) - 1, step: -1}
+}
+
+func (it *sliceIterator) HasNext() bool { return it.pos >= 0 && it.pos < len(it.numbers) }
+func (it *sliceIterator) Next() int {
+	val := it.numbers[it.pos]
+	it.pos += it.step
+	return val
+}
+
+// TreeNode is a binary search tree node
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+// BST is an unbalanced binary search tree; equal values go to the right subtree
+type BST struct {
+	root *TreeNode
+	size int
+}
+
+// Insert adds val to the tree
+// Time Complexity: O(h) where h is the tree height
+func (t *BST) Insert(val int) {
+	t.size++
+	node := &TreeNode{Val: val}
+	if t.root == nil {
+		t.root = node
+		return
+	}
+
+	cur := t.root
+	for {
+		if val < cur.Val {
+			if cur.Left == nil {
+				cur.Left = node
+				return
+			}
+			cur = cur.Left
+		} else {
+			if cur.Right == nil {
+				cur.Right = node
+				return
+			}
+			cur = cur.Right
+		}
+	}
+}
+
+// Len returns the number of values stored
+func (t *BST) Len() int { return t.size }
+
+// Ascend returns an in-order iterator (smallest first)
+func (t *BST) Ascend() Iterator {
+	it := &bstIterator{}
+	it.pushSpine(t.root)
+	return it
+}
+
+// Descend returns a reverse in-order iterator (largest first)
+func (t *BST) Descend() Iterator {
+	it := &bstIterator{reverse: true}
+	it.pushSpine(t.root)
+	return it
+}
+
+// bstIterator keeps only the path to the next node on a stack - O(h) space
+type bstIterator struct {
+	stack   []*TreeNode
+	reverse bool
+}
+
+// pushSpine pushes node and its leftmost (or rightmost, in reverse) descendants
+func (it *bstIterator) pushSpine(node *TreeNode) {
+	for node != nil {
+		it.stack = append(it.stack, node)
+		if it.reverse {
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+}
+
+func (it *bstIterator) HasNext() bool { return len(it.stack) > 0 }
+func (it *bstIterator) Next() int {
+	node := it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	if it.reverse {
+		it.pushSpine(node.Left)
+	} else {
+		it.pushSpine(node.Right)
+	}
+	return node.Val
+}
+
+// Two Sum IV - Input is a BST, solved with the Two Sum II two-pointer walk
+// Time Complexity: O(n), Space Complexity: O(h) - two iterator stacks
+func twoSumBST(tree *BST, target int) (int, int, bool) {
+	return TwoSumIterators(tree.Ascend(), tree.Descend(), tree.Len(), target)
+}
+
 // Helper function to demonstrate how two pointers work step-by-step
 func twoSumWithVisualization(numbers []int, target int) []int {
 	fmt.Printf("Finding two numbers that sum to %d in array: %v\n", target, numbers)
@@ -162,6 +611,198 @@ func main() {
 	result3 := twoSumHashMap(testNumbers, testTarget)
 	fmt.Printf("HashMap:        %v (O(n) time, O(n) space) ❌ Violates constraint\n", result3)
 
+	result4 := twoSumGalloping(testNumbers, testTarget)
+	fmt.Printf("Galloping:      %v (O(log n) skewed / O(n) worst, O(1) space)\n", result4)
+
+	fmt.Println("\n=== Galloping vs Two Pointers on Uniform and Skewed Inputs ===")
+	rng := rand.New(rand.NewSource(42))
+	const size = 1 << 20
+	const rounds = 20
+
+	uniform := make([]int, size)
+	for i := range uniform {
+		uniform[i] = rng.Intn(1 << 40)
+	}
+	sort.Ints(uniform)
+
+	// Power-law values: most are crowded near zero, a few spread out to 2^40
+	skewed := make([]int, size)
+	for i := range skewed {
+		skewed[i] = int(math.Pow(rng.Float64(), 8) * (1 << 40))
+	}
+	sort.Ints(skewed)
+
+	benchmarks := []struct {
+		name    string
+		numbers []int
+		target  int
+	}{
+		// Answer in the middle: both pointers travel about n/2 steps
+		{"uniform, answer in middle", uniform, uniform[size/2-1] + uniform[size/2]},
+		// Answer at the left extreme: two pointers walk right across the whole array
+		{"skewed, answer at left end", skewed, skewed[0] + skewed[1]},
+		// Answer at the right extreme: two pointers walk left across the whole array
+		{"skewed, answer at right end", skewed, skewed[size-2] + skewed[size-1]},
+	}
+	for _, b := range benchmarks {
+		start := time.Now()
+		var linear []int
+		for r := 0; r < rounds; r++ {
+			linear = twoSum(b.numbers, b.target)
+		}
+		linearTime := time.Since(start) / rounds
+
+		start = time.Now()
+		var galloping []int
+		for r := 0; r < rounds; r++ {
+			galloping = twoSumGalloping(b.numbers, b.target)
+		}
+		gallopingTime := time.Since(start) / rounds
+
+		// Any valid pair is fine, the indices may differ when several exist
+		valid := len(galloping) == 2 && b.numbers[galloping[0]-1]+b.numbers[galloping[1]-1] == b.target
+		status := "✅"
+		if !valid || len(linear) != 2 {
+			status = "❌"
+		}
+		fmt.Printf("%-28s two pointers %10v  galloping %10v %s\n",
+			b.name, linearTime, gallopingTime, status)
+	}
+	fmt.Println("Galloping wins when the answer is near an end; on uniform data both are O(n)")
+
+	fmt.Println("\n=== Counting and Enumerating Pairs with Sum in a Range ===")
+	rangeNumbers := []int{-3, 0, 1, 2, 2, 5, 8}
+	rangeCases := []struct{ lo, hi int }{{2, 4}, {-10, 100}, {4, 4}, {20, 30}, {5, 3}}
+	fmt.Printf("sorted = %v\n", rangeNumbers)
+	for _, rc := range rangeCases {
+		count := CountPairsInRange(rangeNumbers, rc.lo, rc.hi)
+
+		// Brute force reference: every pair, checked directly
+		expected := 0
+		for i := 0; i < len(rangeNumbers); i++ {
+			for j := i + 1; j < len(rangeNumbers); j++ {
+				if sum := rangeNumbers[i] + rangeNumbers[j]; sum >= rc.lo && sum <= rc.hi {
+					expected++
+				}
+			}
+		}
+
+		var pairs [][2]int
+		for i, j := range PairsInRange(rangeNumbers, rc.lo, rc.hi) {
+			pairs = append(pairs, [2]int{i, j})
+		}
+
+		status := "✅"
+		if count != expected || len(pairs) != expected {
+			status = "❌"
+		}
+		fmt.Printf("sum in [%d, %d]: count %d, pairs %v %s\n", rc.lo, rc.hi, count, pairs, status)
+	}
+	fmt.Printf("PairsLessThan(sorted, 2) = %d\n", PairsLessThan(rangeNumbers, 2))
+
+	fmt.Println("\n=== Two Sum over a BST and Sorted Iterators ===")
+	treeValues := []int{5, 3, 8, 1, 4, 7, 9, 3, -2}
+	tree := &BST{}
+	for _, v := range treeValues {
+		tree.Insert(v)
+	}
+	sortedValues := append([]int(nil), treeValues...)
+	sort.Ints(sortedValues)
+	fmt.Printf("BST built from %v (in-order: %v)\n", treeValues, sortedValues)
+
+	for _, target := range []int{6, 17, -1, 2, 100} {
+		a, b, found := twoSumBST(tree, target)
+		sliceA, sliceB, sliceFound := TwoSumIterators(
+			ascendSlice(sortedValues), descendSlice(sortedValues), len(sortedValues), target)
+		expected := len(twoSum(sortedValues, target)) == 2
+
+		status := "✅"
+		if found != expected || sliceFound != expected || (found && (a+b != target || a != sliceA || b != sliceB)) {
+			status = "❌"
+		}
+		if found {
+			fmt.Printf("target %3d: %d + %d %s\n", target, a, b, status)
+		} else {
+			fmt.Printf("target %3d: no pair %s\n", target, status)
+		}
+	}
+
+	fmt.Println("\n=== Overflow-Checked Two Pointers at Integer Boundaries ===")
+	// Plain twoSum sees MaxInt + 1 wrap to MinInt, "too small", and walks past the answer
+	wrapNumbers := []int{1, math.MaxInt - 1, math.MaxInt}
+	fmt.Printf("twoSum([1 MaxInt-1 MaxInt], MaxInt) = %v ❌ expected [1 2]\n",
+		twoSum(wrapNumbers, math.MaxInt))
+
+	boundaryCases := []struct {
+		name     string
+		run      func() []int
+		expected []int
+	}{
+		{"int: [1 MaxInt-1 MaxInt], target MaxInt", func() []int {
+			return TwoSumChecked([]int{1, math.MaxInt - 1, math.MaxInt}, math.MaxInt)
+		}, []int{1, 2}},
+		{"int64: [MinInt64 -1 MaxInt64], target -1", func() []int {
+			return TwoSumChecked([]int64{math.MinInt64, -1, math.MaxInt64}, -1)
+		}, []int{1, 3}},
+		{"int64: [MinInt64 MinInt64 0], target MinInt64", func() []int {
+			return TwoSumChecked([]int64{math.MinInt64, math.MinInt64, 0}, math.MinInt64)
+		}, []int{1, 3}},
+		{"int64: [MaxInt64 MaxInt64], target -2", func() []int {
+			return TwoSumChecked([]int64{math.MaxInt64, math.MaxInt64}, -2)
+		}, []int{}},
+		{"uint64: [0 1 MaxUint64], target MaxUint64", func() []int {
+			return TwoSumChecked([]uint64{0, 1, math.MaxUint64}, math.MaxUint64)
+		}, []int{1, 3}},
+		{"uint64: [2 MaxUint64], target 1", func() []int {
+			return TwoSumChecked([]uint64{2, math.MaxUint64}, 1)
+		}, []int{}},
+		{"galloping: [1 MaxInt-1 MaxInt], target MaxInt", func() []int {
+			return twoSumGalloping([]int{1, math.MaxInt - 1, math.MaxInt}, math.MaxInt)
+		}, []int{1, 2}},
+		{"galloping: [-5 -1], target MaxInt", func() []int {
+			return twoSumGalloping([]int{-5, -1}, math.MaxInt)
+		}, []int{}},
+		{"galloping: [1 5], target MinInt", func() []int {
+			return twoSumGalloping([]int{1, 5}, math.MinInt)
+		}, []int{}},
+	}
+	for _, tc := range boundaryCases {
+		result := tc.run()
+		status := "✅"
+		if fmt.Sprint(result) != fmt.Sprint(tc.expected) {
+			status = "❌"
+		}
+		fmt.Printf("%-46s -> %v %s\n", tc.name, result, status)
+	}
+
+	fmt.Println("\n=== Two Sum II over a Sorted Random-Access Source ===")
+	values := []int64{-10, -5, -3, 0, 1, 3, 5, 12, math.MaxInt64}
+	// Encode the values the way a file of fixed-width records would store them
+	raw := make([]byte, 8*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(raw[i*8:], uint64(v))
+	}
+
+	sources := []struct {
+		name string
+		src  SortedSource
+	}{
+		{"Int64Slice", Int64Slice(values)},
+		{"Int64File (raw bytes)", Int64File(raw)},
+	}
+	for _, target := range []int64{-8, 17, math.MaxInt64 - 10, 100} {
+		for _, s := range sources {
+			twoPointers := TwoSumSource(s.src, target)
+			binarySearch := TwoSumBinarySearchSource(s.src, target)
+			status := "✅"
+			if fmt.Sprint(twoPointers) != fmt.Sprint(binarySearch) {
+				status = "❌"
+			}
+			fmt.Printf("%-22s target %-20d two pointers %v, binary search %v %s\n",
+				s.name, target, twoPointers, binarySearch, status)
+		}
+	}
+
 	fmt.Println("\n=== Why Two Pointers Works for Sorted Arrays ===")
 	fmt.Println("Key insights:")
 	fmt.Println("1. Array is SORTED - we can use this property!")
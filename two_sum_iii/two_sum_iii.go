@@ -0,0 +1,177 @@
+package main
+
+import "fmt"
+
+// Two Sum III - Data Structure Design
+// Keep a changing multiset of numbers and answer "does any pair sum to target?"
+// without rebuilding the lookup map on every query like twoSum does.
+
+// Strategy picks which operation pays for the bookkeeping
+type Strategy int
+
+const (
+	// AddOptimized: Add/Remove O(1), Find O(n) - best when writes dominate
+	AddOptimized Strategy = iota
+	// FindOptimized: Add/Remove O(n), Find O(1) - best when queries dominate
+	FindOptimized
+)
+
+// TwoSumIndex stores numbers with their multiplicity so duplicates and
+// removals are handled correctly (e.g. Find(6) with a single 3 is false).
+type TwoSumIndex struct {
+	strategy Strategy
+	counts   map[int]int // number -> how many times it was added
+	sums     map[int]int // FindOptimized only: pair sum -> number of pairs
+}
+
+// NewTwoSumIndex creates an empty index using the given strategy
+func NewTwoSumIndex(strategy Strategy) *TwoSumIndex {
+	index := &TwoSumIndex{
+		strategy: strategy,
+		counts:   make(map[int]int),
+	}
+	if strategy == FindOptimized {
+		index.sums = make(map[int]int)
+	}
+	return index
+}
+
+// Add inserts x into the index
+// Time Complexity: O(1) add-optimized, O(u) find-optimized (u = unique numbers)
+func (t *TwoSumIndex) Add(x int) {
+	if t.strategy == FindOptimized {
+		// Every number already stored forms new pairs with x
+		for num, count := range t.counts {
+			t.sums[num+x] += count
+		}
+	}
+	t.counts[x]++
+}
+
+// Remove deletes one occurrence of x, reporting whether x was present
+// Time Complexity: O(1) add-optimized, O(u) find-optimized
+func (t *TwoSumIndex) Remove(x int) bool {
+	if t.counts[x] == 0 {
+		return false
+	}
+
+	t.counts[x]--
+	if t.counts[x] == 0 {
+		delete(t.counts, x)
+	}
+
+	if t.strategy == FindOptimized {
+		// Undo the pairs x formed with every remaining number
+		for num, count := range t.counts {
+			sum := num + x
+			t.sums[sum] -= count
+			if t.sums[sum] == 0 {
+				delete(t.sums, sum)
+			}
+		}
+	}
+	return true
+}
+
+// Find reports whether two stored numbers (distinct occurrences) sum to target
+// Time Complexity: O(u) add-optimized, O(1) find-optimized
+func (t *TwoSumIndex) Find(target int) bool {
+	if t.strategy == FindOptimized {
+		return t.sums[target] > 0
+	}
+
+	for num, count := range t.counts {
+		complement := target - num
+		if complement == num {
+			// Same value needs to appear at least twice
+			if count > 1 {
+				return true
+			}
+		} else if t.counts[complement] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns how many numbers (with duplicates) are stored
+func (t *TwoSumIndex) Len() int {
+	total := 0
+	for _, count := range t.counts {
+		total += count
+	}
+	return total
+}
+
+func main() {
+	fmt.Println("=== Two Sum III - Data Structure Design ===")
+	fmt.Println("Add numbers incrementally, then answer many target queries")
+	fmt.Println()
+
+	type step struct {
+		op       string // "add", "remove" or "find"
+		value    int
+		expected bool
+	}
+
+	// Example from the problem, extended with duplicates and removals
+	steps := []step{
+		{"add", 1, true},
+		{"add", 3, true},
+		{"add", 5, true},
+		{"find", 4, true},
+		{"find", 7, false},
+		{"find", 6, true},
+		{"find", 10, false}, // only one 5 stored
+		{"add", 5, true},
+		{"find", 10, true},
+		{"remove", 5, true},
+		{"find", 10, false},
+		{"remove", 3, true},
+		{"find", 4, false},
+		{"find", 6, true},
+		{"remove", 42, false}, // never added
+	}
+
+	strategies := []struct {
+		strategy Strategy
+		name     string
+	}{
+		{AddOptimized, "Add-optimized (Add O(1), Find O(n))"},
+		{FindOptimized, "Find-optimized (Add O(n), Find O(1))"},
+	}
+
+	for _, s := range strategies {
+		fmt.Printf("--- %s ---\n", s.name)
+		index := NewTwoSumIndex(s.strategy)
+		allPassed := true
+
+		for _, st := range steps {
+			result := true
+			switch st.op {
+			case "add":
+				index.Add(st.value)
+				fmt.Printf("Add(%d)\n", st.value)
+				continue
+			case "remove":
+				result = index.Remove(st.value)
+			case "find":
+				result = index.Find(st.value)
+			}
+
+			status := "✅"
+			if result != st.expected {
+				status = "❌"
+				allPassed = false
+			}
+			fmt.Printf("%s(%d) = %v %s\n", st.op, st.value, result, status)
+		}
+		fmt.Printf("Stored numbers: %d, all passed: %v\n\n", index.Len(), allPassed)
+	}
+
+	fmt.Println("=== Choosing a Strategy ===")
+	fmt.Println("• Add-optimized: one map of counts, Find scans unique numbers")
+	fmt.Println("• Find-optimized: also keeps every pair sum, Find is a single lookup")
+	fmt.Println("• Slowly changing set + many queries → Find-optimized")
+	fmt.Println("• Many writes + occasional queries → Add-optimized")
+}
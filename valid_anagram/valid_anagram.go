@@ -1,13 +1,23 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"maps"
+	"math/rand"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Approach 1: Using array for character frequency count (FASTEST & MOST EFFICIENT)
 // Time: O(n), Space: O(1) - using fixed array of size 26
+// Input outside lowercase a-z falls back to comparing runes
 func isAnagram(s string, t string) bool {
 	// If lengths are different, they can't be anagrams
 	if len(s) != len(t) {
@@ -20,6 +30,9 @@ func isAnagram(s string, t string) bool {
 
 	// Single pass through both strings simultaneously
 	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' || t[i] < 'a' || t[i] > 'z' {
+			return isAnagramWith(s, t, AnagramOptions{})
+		}
 		count[s[i]-'a']++ // Increment count for character in s
 		count[t[i]-'a']-- // Decrement count for character in t
 	}
@@ -42,10 +55,13 @@ func isAnagramHashMap(s string, t string) bool {
 		return false
 	}
 
+	// Range both strings by rune - indexing t by byte splits multi-byte characters
 	countS, countT := make(map[rune]int), make(map[rune]int)
-	for i, ch := range s {
+	for _, ch := range s {
 		countS[ch]++
-		countT[rune(t[i])]++
+	}
+	for _, ch := range t {
+		countT[ch]++
 	}
 
 	for k, v := range countS {
@@ -78,6 +94,612 @@ func isAnagramSort(s string, t string) bool {
 	return string(sRunes) == string(tRunes)
 }
 
+// Unit is what counts as one "character" when comparing with options
+type Unit int
+
+const (
+	// RuneUnit compares Unicode code points (the default)
+	RuneUnit Unit = iota
+	// ByteUnit compares raw bytes; folding and ignoring only apply to ASCII bytes
+	ByteUnit
+	// GraphemeUnit compares a base rune together with its combining marks,
+	// so "e" + U+0301 is one unit and is not an anagram of U+0301 + "e"
+	GraphemeUnit
+)
+
+// AnagramOptions configures the *With / option-taking variants
+// The zero value compares runes exactly
+type AnagramOptions struct {
+	Unit        Unit
+	FoldCase    bool // 'A' matches 'a' (Unicode simple case folding)
+	IgnoreSpace bool // drop whitespace before comparing
+	IgnorePunct bool // drop punctuation before comparing
+}
+
+// skip reports whether r is dropped before comparing
+func (o AnagramOptions) skip(r rune) bool {
+	return (o.IgnoreSpace && unicode.IsSpace(r)) || (o.IgnorePunct && unicode.IsPunct(r))
+}
+
+// fold maps r to the representative of its case-folding orbit when FoldCase is set
+func (o AnagramOptions) fold(r rune) rune {
+	if !o.FoldCase {
+		return r
+	}
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		smallest = min(smallest, f)
+	}
+	return smallest
+}
+
+// foldByte applies skip and fold to a single byte; bytes >= 0x80 are kept as is
+func (o AnagramOptions) foldByte(b byte) (byte, bool) {
+	if b >= utf8.RuneSelf {
+		return b, true
+	}
+	if o.skip(rune(b)) {
+		return 0, false
+	}
+	return byte(o.fold(rune(b))), true
+}
+
+// units splits s into the comparison units selected by the options
+func (o AnagramOptions) units(s string) []string {
+	var result []string
+	switch o.Unit {
+	case ByteUnit:
+		for i := 0; i < len(s); i++ {
+			if b, keep := o.foldByte(s[i]); keep {
+				result = append(result, string([]byte{b}))
+			}
+		}
+	case GraphemeUnit:
+		var cluster []rune
+		for _, r := range s {
+			// Combining marks attach to the cluster in progress
+			if unicode.Is(unicode.M, r) && len(cluster) > 0 {
+				cluster = append(cluster, o.fold(r))
+				continue
+			}
+			if len(cluster) > 0 {
+				result = append(result, string(cluster))
+				cluster = cluster[:0]
+			}
+			if !o.skip(r) {
+				cluster = append(cluster, o.fold(r))
+			}
+		}
+		if len(cluster) > 0 {
+			result = append(result, string(cluster))
+		}
+	default:
+		for _, r := range s {
+			if !o.skip(r) {
+				result = append(result, string(o.fold(r)))
+			}
+		}
+	}
+	return result
+}
+
+// Approach 1 with options: counting array where it fits, map otherwise
+// Bytes use a [256]int array; runes and graphemes a [128]int array for ASCII
+// plus a map for the rest (other runes, or clusters with combining marks)
+// Time: O(n), Space: O(1) for bytes, O(distinct characters) otherwise
+func isAnagramWith(s, t string, opts AnagramOptions) bool {
+	switch opts.Unit {
+	case ByteUnit:
+		count := [256]int{}
+		for i := 0; i < len(s); i++ {
+			if b, keep := opts.foldByte(s[i]); keep {
+				count[b]++
+			}
+		}
+		for i := 0; i < len(t); i++ {
+			if b, keep := opts.foldByte(t[i]); keep {
+				count[b]--
+			}
+		}
+		for _, c := range count {
+			if c != 0 {
+				return false
+			}
+		}
+		return true
+
+	case GraphemeUnit:
+		// Clusters are counted as they complete, without building a unit slice:
+		// a lone ASCII rune goes to the array, any other cluster to the map
+		ascii := [utf8.RuneSelf]int{}
+		others := make(map[string]int)
+		var cluster []rune
+		flush := func(delta int) {
+			if len(cluster) == 1 && cluster[0] < utf8.RuneSelf {
+				ascii[cluster[0]] += delta
+			} else if len(cluster) > 0 {
+				others[string(cluster)] += delta
+			}
+			cluster = cluster[:0]
+		}
+		tally := func(str string, delta int) {
+			for _, r := range str {
+				if len(cluster) > 0 && unicode.Is(unicode.M, r) {
+					cluster = append(cluster, opts.fold(r))
+					continue
+				}
+				flush(delta)
+				if !opts.skip(r) {
+					cluster = append(cluster, opts.fold(r))
+				}
+			}
+			flush(delta)
+		}
+		tally(s, 1)
+		tally(t, -1)
+
+		for _, c := range ascii {
+			if c != 0 {
+				return false
+			}
+		}
+		for _, c := range others {
+			if c != 0 {
+				return false
+			}
+		}
+		return true
+
+	default:
+		ascii := [utf8.RuneSelf]int{}
+		others := make(map[rune]int)
+		tally := func(str string, delta int) {
+			for _, r := range str {
+				if opts.skip(r) {
+					continue
+				}
+				r = opts.fold(r)
+				if r < utf8.RuneSelf {
+					ascii[r] += delta
+				} else {
+					others[r] += delta
+				}
+			}
+		}
+		tally(s, 1)
+		tally(t, -1)
+
+		for _, c := range ascii {
+			if c != 0 {
+				return false
+			}
+		}
+		for _, c := range others {
+			if c != 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Approach 2 with options: HashMap of units
+// Time: O(n), Space: O(distinct units)
+func isAnagramHashMapWith(s, t string, opts AnagramOptions) bool {
+	count := make(map[string]int)
+	for _, unit := range opts.units(s) {
+		count[unit]++
+	}
+	for _, unit := range opts.units(t) {
+		count[unit]--
+		if count[unit] < 0 {
+			return false
+		}
+	}
+	for _, c := range count {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Approach 3 with options: sort both unit lists and compare
+// Time: O(n log n), Space: O(n)
+func isAnagramSortWith(s, t string, opts AnagramOptions) bool {
+	sUnits, tUnits := opts.units(s), opts.units(t)
+	if len(sUnits) != len(tUnits) {
+		return false
+	}
+	slices.Sort(sUnits)
+	slices.Sort(tUnits)
+	return slices.Equal(sUnits, tUnits)
+}
+
+// CharCount is one character and how many times it is over or under
+type CharCount struct {
+	Char  rune
+	Count int
+}
+
+// AnagramDiffReport explains why t is (or is not) an anagram of s
+type AnagramDiffReport struct {
+	Extra   []CharCount // characters t has more of than s, sorted by character
+	Missing []CharCount // characters t has fewer of than s, sorted by character
+	// Replacements is the minimum number of characters of t to change so it
+	// becomes an anagram of s (LeetCode 1347), or -1 if s and t have different
+	// numbers of characters (runes), so no replacements alone can do it
+	Replacements int
+	// Deletions is the minimum number of characters to delete from s and t
+	// combined so the remainders are anagrams of each other
+	Deletions int
+}
+
+// AnagramDiff compares the character counts of s and t, like isAnagram
+// Uses the [26]int counter for lowercase a-z and a map of runes otherwise
+// Time: O(n + d log d) for d differing characters, Space: O(d)
+func AnagramDiff(s, t string) AnagramDiffReport {
+	// count[c] = count in t - count in s
+	count := [26]int{}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return anagramDiffRunes(s, t)
+		}
+		count[s[i]-'a']--
+	}
+	for i := 0; i < len(t); i++ {
+		if t[i] < 'a' || t[i] > 'z' {
+			return anagramDiffRunes(s, t)
+		}
+		count[t[i]-'a']++
+	}
+
+	// The array is already in character order, so nothing needs sorting
+	report := AnagramDiffReport{}
+	for i, c := range count {
+		report.add(rune('a'+i), c)
+	}
+	report.total()
+	return report
+}
+
+// anagramDiffRunes is AnagramDiff for any Unicode text, with a map of runes
+func anagramDiffRunes(s, t string) AnagramDiffReport {
+	diff := make(map[rune]int)
+	for _, ch := range s {
+		diff[ch]--
+	}
+	for _, ch := range t {
+		diff[ch]++
+	}
+
+	report := AnagramDiffReport{}
+	for _, ch := range slices.Sorted(maps.Keys(diff)) {
+		report.add(ch, diff[ch])
+	}
+	report.total()
+	return report
+}
+
+// add records that t has c more of ch than s (c < 0: fewer); calls must be in character order
+func (r *AnagramDiffReport) add(ch rune, c int) {
+	if c > 0 {
+		r.Extra = append(r.Extra, CharCount{ch, c})
+	} else if c < 0 {
+		r.Missing = append(r.Missing, CharCount{ch, -c})
+	}
+}
+
+// total fills in Replacements and Deletions from Extra and Missing
+func (r *AnagramDiffReport) total() {
+	extra, missing := 0, 0
+	for _, cc := range r.Extra {
+		extra += cc.Count
+	}
+	for _, cc := range r.Missing {
+		missing += cc.Count
+	}
+
+	// Each replacement fixes one extra and one missing character at once
+	r.Replacements = -1
+	if extra == missing {
+		r.Replacements = extra
+	}
+	r.Deletions = extra + missing
+}
+
+// String formats the report for display, e.g. "missing: a×1; extra: z×2"
+func (r AnagramDiffReport) String() string {
+	if len(r.Extra) == 0 && len(r.Missing) == 0 {
+		return "anagrams"
+	}
+	format := func(counts []CharCount) string {
+		parts := make([]string, len(counts))
+		for i, cc := range counts {
+			parts[i] = fmt.Sprintf("%c×%d", cc.Char, cc.Count)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	var parts []string
+	if len(r.Missing) > 0 {
+		parts = append(parts, "missing: "+format(r.Missing))
+	}
+	if len(r.Extra) > 0 {
+		parts = append(parts, "extra: "+format(r.Extra))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ReaderMismatch describes why two readers are not anagrams
+type ReaderMismatch struct {
+	// SizeOnly is set when known sizes differed, so nothing was read
+	SizeA, SizeB int64
+	SizeOnly     bool
+
+	// Otherwise, the symbol whose counts diverged for good: of the symbols
+	// still unbalanced, the one that has been unbalanced the longest
+	Symbol rune  // the byte value when comparing bytes
+	CountA int64 // occurrences read from r1 when comparison stopped
+	CountB int64 // occurrences read from r2 when comparison stopped
+	Reader int   // 1 or 2, the reader Offset refers to
+	Offset int64 // byte offset in Reader of the occurrence that unbalanced the counts
+}
+
+func (m ReaderMismatch) String() string {
+	if m.SizeOnly {
+		return fmt.Sprintf("sizes differ: %d vs %d bytes", m.SizeA, m.SizeB)
+	}
+	return fmt.Sprintf("%q read %d vs %d times (counts diverged at byte %d of reader %d)",
+		m.Symbol, m.CountA, m.CountB, m.Offset, m.Reader)
+}
+
+// readerBufferSize bounds the memory used per reader by IsAnagramReader
+const readerBufferSize = 64 * 1024
+
+// symbolStats is the per-symbol counter shared by byte and rune mode
+type symbolStats struct {
+	count  [2]int64
+	since  int64 // when the counts last went from equal to unequal: 2*step + reader index
+	reader int   // reader whose symbol unbalanced them, 1 or 2
+	offset int64 // byte offset of that symbol in its reader
+}
+
+// IsAnagramReader streams r1 and r2 through frequency counters and reports
+// whether they hold the same multiset of bytes (ByteUnit) or runes (any other
+// unit; input is assumed to be valid UTF-8). Both readers are consumed in
+// lockstep, one symbol from each per step through 64 KB buffers, keeping the
+// running count difference of every symbol. Memory is bounded by the buffers
+// plus one counter per distinct symbol.
+// Reading stops early once the answer can only be false: when both sizes are
+// known up front (strings.Reader, bytes.Reader, regular files) and differ,
+// nothing is read; once one reader is exhausted, the first symbol the other
+// has more of ends the comparison.
+// Time: O(n), Space: O(buffer + distinct symbols)
+func IsAnagramReader(r1, r2 io.Reader, unit Unit) (bool, *ReaderMismatch, error) {
+	sizeA, knownA := knownSize(r1)
+	sizeB, knownB := knownSize(r2)
+	if knownA && knownB && sizeA != sizeB {
+		return false, &ReaderMismatch{SizeA: sizeA, SizeB: sizeB, SizeOnly: true}, nil
+	}
+
+	var bytesStats [256]symbolStats
+	runeStats := make(map[rune]*symbolStats)
+	statsFor := func(sym rune) *symbolStats {
+		if unit == ByteUnit {
+			return &bytesStats[sym]
+		}
+		st, exists := runeStats[sym]
+		if !exists {
+			st = &symbolStats{}
+			runeStats[sym] = st
+		}
+		return st
+	}
+	mismatchOf := func(sym rune, st *symbolStats) *ReaderMismatch {
+		return &ReaderMismatch{Symbol: sym, CountA: st.count[0], CountB: st.count[1],
+			Reader: st.reader, Offset: st.offset}
+	}
+
+	// longestUnbalanced picks, among the symbols surplus accepts, the one
+	// whose counts have been unequal the longest
+	longestUnbalanced := func(surplus func(st *symbolStats) bool) *ReaderMismatch {
+		var mismatch *ReaderMismatch
+		var since int64
+		consider := func(sym rune, st *symbolStats) {
+			if st.count[0] != st.count[1] && surplus(st) && (mismatch == nil || st.since < since) {
+				mismatch, since = mismatchOf(sym, st), st.since
+			}
+		}
+		if unit == ByteUnit {
+			for b := range bytesStats {
+				consider(rune(b), &bytesStats[b])
+			}
+		} else {
+			for sym, st := range runeStats {
+				consider(sym, st)
+			}
+		}
+		return mismatch
+	}
+
+	readers := [2]*bufio.Reader{
+		bufio.NewReaderSize(r1, readerBufferSize),
+		bufio.NewReaderSize(r2, readerBufferSize),
+	}
+	var offsets [2]int64
+	var done [2]bool
+	for step := int64(0); !done[0] || !done[1]; step++ {
+		bothRunning := !done[0] && !done[1]
+		for which, reader := range readers {
+			if done[which] {
+				continue
+			}
+			other := 1 - which
+
+			var sym rune
+			var size int
+			var err error
+			if unit == ByteUnit {
+				var b byte
+				b, err = reader.ReadByte()
+				sym, size = rune(b), 1
+			} else {
+				sym, size, err = reader.ReadRune()
+			}
+			if err == io.EOF {
+				done[which] = true
+				continue
+			}
+			if err != nil {
+				return false, nil, fmt.Errorf("anagram reader %d: at byte %d: %w", which+1, offsets[which], err)
+			}
+
+			st := statsFor(sym)
+			if st.count[0] == st.count[1] {
+				st.since, st.reader, st.offset = 2*step+int64(which), which+1, offsets[which]
+			}
+			st.count[which]++
+			offsets[which] += int64(size)
+
+			if done[other] && st.count[which] > st.count[other] {
+				return false, mismatchOf(sym, st), nil
+			}
+		}
+
+		if bothRunning && done[0] != done[1] {
+			// One reader just ended: what the other has more of can never be matched now
+			finished, running := 0, 1
+			if done[1] {
+				finished, running = 1, 0
+			}
+			mismatch := longestUnbalanced(func(st *symbolStats) bool {
+				return st.count[running] > st.count[finished]
+			})
+			if mismatch != nil {
+				return false, mismatch, nil
+			}
+		}
+	}
+
+	mismatch := longestUnbalanced(func(*symbolStats) bool { return true })
+	return mismatch == nil, mismatch, nil
+}
+
+// knownSize returns how many bytes are left in r, when that is cheap to know
+func knownSize(r io.Reader) (int64, bool) {
+	switch v := r.(type) {
+	case interface{ Len() int }: // strings.Reader, bytes.Reader, bytes.Buffer
+		return int64(v.Len()), true
+	case *os.File:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		pos, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - pos, true
+	}
+	return 0, false
+}
+
+// SWAR masks: one copy of a byte value in each of the 8 bytes of a uint64
+const (
+	swarOnes  = 0x0101010101010101
+	swarHighs = 0x8080808080808080
+)
+
+// Approach 4: Word-parallel (SWAR) counting for long lowercase strings
+// Reads 8 bytes of each string as one uint64, validates all 8 are a-z with a
+// few word-wide additions, then updates the counters unrolled 8 at a time.
+// The updates rotate through 4 counter tables, so consecutive increments of the
+// same letter do not wait on each other's store; the tables are summed at the end.
+// Input outside a-z falls back to isAnagram.
+// Time: O(n) with ~8x fewer loop iterations, Space: O(1) - 4 tables of 256 ints
+func isAnagramSWAR(s string, t string) bool {
+	if len(s) != len(t) {
+		return false
+	}
+
+	// Indexed by the byte itself: a byte index into 256 entries needs no bounds check
+	var count [4][256]int
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		ws, wt := load64(s, i), load64(t, i)
+		if !allLettersSWAR(ws) || !allLettersSWAR(wt) {
+			return isAnagram(s, t)
+		}
+
+		count[0][byte(ws)]++
+		count[1][byte(ws>>8)]++
+		count[2][byte(ws>>16)]++
+		count[3][byte(ws>>24)]++
+		count[0][byte(ws>>32)]++
+		count[1][byte(ws>>40)]++
+		count[2][byte(ws>>48)]++
+		count[3][byte(ws>>56)]++
+
+		count[0][byte(wt)]--
+		count[1][byte(wt>>8)]--
+		count[2][byte(wt>>16)]--
+		count[3][byte(wt>>24)]--
+		count[0][byte(wt>>32)]--
+		count[1][byte(wt>>40)]--
+		count[2][byte(wt>>48)]--
+		count[3][byte(wt>>56)]--
+	}
+
+	// Tail of fewer than 8 bytes, one at a time
+	for ; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' || t[i] < 'a' || t[i] > 'z' {
+			return isAnagram(s, t)
+		}
+		count[0][s[i]]++
+		count[0][t[i]]--
+	}
+
+	for letter := 'a'; letter <= 'z'; letter++ {
+		if count[0][letter]+count[1][letter]+count[2][letter]+count[3][letter] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// load64 reads s[i:i+8] as a little-endian uint64
+// The compiler merges these byte loads into a single 8-byte load
+func load64(s string, i int) uint64 {
+	_ = s[i+7] // one bounds check for all 8 bytes
+	return uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+		uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+}
+
+// allLettersSWAR reports whether all 8 bytes of w are in 'a'..'z'
+// For an ASCII byte x: x >= 'a' exactly when x + (0x80-'a') sets the high bit,
+// and x <= 'z' exactly when x + (0x80-'z'-1) leaves it clear. With every high
+// bit clear beforehand, neither addition can carry into the next byte.
+func allLettersSWAR(w uint64) bool {
+	if w&swarHighs != 0 {
+		return false // some byte is not ASCII
+	}
+	atLeastA := (w + (0x80-'a')*swarOnes) & swarHighs
+	aboveZ := (w + (0x80-'z'-1)*swarOnes) & swarHighs
+	return atLeastA == swarHighs && aboveZ == 0
+}
+
+// randomText builds a short string from a mix that exercises every option:
+// upper/lower case, spaces, punctuation, multi-byte runes, combining marks
+func randomText(rng *rand.Rand) string {
+	pieces := []string{"a", "b", "A", "B", " ", "\t", ",", "!", "é", "É", "\u0301", "ß", "ẞ", "k", "K", "\u212A", "日", "ñ"}
+	var sb strings.Builder
+	for n := rng.Intn(8); n > 0; n-- {
+		sb.WriteString(pieces[rng.Intn(len(pieces))])
+	}
+	return sb.String()
+}
+
 func main() {
 	// Test cases
 	testCases := []struct {
@@ -117,6 +739,227 @@ func main() {
 		fmt.Println(strings.Repeat("-", 30))
 	}
 
+	fmt.Println("\n=== Unicode Input (previously panicked or miscounted) ===")
+	unicodeCases := []struct {
+		s, t     string
+		expected bool
+	}{
+		{"Listen", "Silent", false}, // 'L' vs 'S' - case matters by default
+		{"café", "éfac", true},
+		{"日本語", "語日本", true},
+		{"añb", "ñab", true},
+		{"añb", "nab", false},
+	}
+	for _, tc := range unicodeCases {
+		r1, r2, r3 := isAnagram(tc.s, tc.t), isAnagramHashMap(tc.s, tc.t), isAnagramSort(tc.s, tc.t)
+		status := "✅"
+		if r1 != tc.expected || r2 != tc.expected || r3 != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("%q vs %q: array=%t hashmap=%t sort=%t %s\n", tc.s, tc.t, r1, r2, r3, status)
+	}
+
+	fmt.Println("\n=== Options: Units, Case Folding, Ignoring Spaces and Punctuation ===")
+	optionCases := []struct {
+		s, t     string
+		opts     AnagramOptions
+		expected bool
+		name     string
+	}{
+		{"Listen", "Silent", AnagramOptions{FoldCase: true}, true, "fold case"},
+		{"Dormitory", "dirty room", AnagramOptions{FoldCase: true, IgnoreSpace: true}, true, "fold case + ignore space"},
+		{"A gentleman!", "Elegant man.", AnagramOptions{FoldCase: true, IgnoreSpace: true, IgnorePunct: true}, true, "ignore punctuation"},
+		{"Straße", "STRASSE", AnagramOptions{FoldCase: true}, false, "ß is one rune, SS is two"},
+		{"e\u0301a", "ae\u0301", AnagramOptions{Unit: GraphemeUnit}, true, "graphemes keep the accent on e"},
+		{"e\u0301a", "a\u0301e", AnagramOptions{Unit: GraphemeUnit}, false, "accent moved to a"},
+		{"e\u0301a", "a\u0301e", AnagramOptions{Unit: RuneUnit}, true, "as runes the accent is free"},
+		{"ab", "BA", AnagramOptions{Unit: ByteUnit, FoldCase: true}, true, "bytes with ASCII folding"},
+		{"é", "É", AnagramOptions{Unit: ByteUnit, FoldCase: true}, false, "bytes do not fold UTF-8"},
+	}
+	for _, tc := range optionCases {
+		r1, r2, r3 := isAnagramWith(tc.s, tc.t, tc.opts), isAnagramHashMapWith(tc.s, tc.t, tc.opts), isAnagramSortWith(tc.s, tc.t, tc.opts)
+		status := "✅"
+		if r1 != tc.expected || r2 != tc.expected || r3 != tc.expected {
+			status = "❌"
+		}
+		fmt.Printf("%-32s %q vs %q: %t %s\n", tc.name+":", tc.s, tc.t, r1, status)
+	}
+
+	// Fuzz: every option combination, all three approaches must agree
+	rng := rand.New(rand.NewSource(1))
+	disagreements := 0
+	for trial := 0; trial < 20000; trial++ {
+		s, t := randomText(rng), randomText(rng)
+		if rng.Intn(2) == 0 {
+			// Shuffle s into t so true anagrams are common too
+			runes := []rune(s)
+			rng.Shuffle(len(runes), func(i, j int) { runes[i], runes[j] = runes[j], runes[i] })
+			t = string(runes)
+		}
+		opts := AnagramOptions{
+			Unit:        Unit(rng.Intn(3)),
+			FoldCase:    rng.Intn(2) == 0,
+			IgnoreSpace: rng.Intn(2) == 0,
+			IgnorePunct: rng.Intn(2) == 0,
+		}
+		r1, r2, r3 := isAnagramWith(s, t, opts), isAnagramHashMapWith(s, t, opts), isAnagramSortWith(s, t, opts)
+		if r1 != r2 || r2 != r3 {
+			if disagreements == 0 {
+				fmt.Printf("Disagreement: %q vs %q with %+v: %t %t %t\n", s, t, opts, r1, r2, r3)
+			}
+			disagreements++
+		}
+		// Without options the original three functions must agree as well
+		if isAnagram(s, t) != isAnagramHashMap(s, t) || isAnagram(s, t) != isAnagramSort(s, t) {
+			disagreements++
+		}
+	}
+	if disagreements == 0 {
+		fmt.Println("Fuzzing: 20000 random inputs, all approaches agree ✅")
+	} else {
+		fmt.Printf("Fuzzing: %d disagreements ❌\n", disagreements)
+	}
+
+	fmt.Println("\n=== Anagram Diff: What Is Missing or Extra ===")
+	diffCases := []struct {
+		s, t                    string
+		replacements, deletions int
+	}{
+		{"bab", "aba", 1, 2},            // LeetCode 1347 Example 1
+		{"leetcode", "practice", 5, 10}, // LeetCode 1347 Example 2
+		{"anagram", "mangaar", 0, 0},    // LeetCode 1347 Example 3
+		{"cde", "abc", 2, 4},            // Making Anagrams: delete d, e and a, b
+		{"hello", "hell", -1, 1},        // different lengths: only deletions work
+		{"café", "face", 1, 2},          // Unicode goes through the map counter
+	}
+	for _, tc := range diffCases {
+		report := AnagramDiff(tc.s, tc.t)
+		status := "✅"
+		if report.Replacements != tc.replacements || report.Deletions != tc.deletions ||
+			(report.Deletions == 0) != isAnagram(tc.s, tc.t) {
+			status = "❌"
+		}
+		fmt.Printf("%q vs %q: %s (replacements: %d, deletions: %d) %s\n",
+			tc.s, tc.t, report, report.Replacements, report.Deletions, status)
+	}
+
+	fmt.Println("\n=== Streaming Comparison of Two io.Readers ===")
+	readerCases := []struct {
+		name     string
+		r1, r2   func() io.Reader
+		unit     Unit
+		expected bool
+	}{
+		{"strings, anagrams", func() io.Reader { return strings.NewReader("listen") },
+			func() io.Reader { return strings.NewReader("silent") }, RuneUnit, true},
+		{"known sizes differ", func() io.Reader { return strings.NewReader("abc") },
+			func() io.Reader { return strings.NewReader("abcd") }, ByteUnit, false},
+		// io.MultiReader hides the size, so these have to be read to the end
+		{"unknown sizes, anagrams", func() io.Reader { return io.MultiReader(strings.NewReader("backup-"), strings.NewReader("2024")) },
+			func() io.Reader { return io.MultiReader(strings.NewReader("4202-pukcab")) }, ByteUnit, true},
+		{"unknown sizes, diverging", func() io.Reader { return io.MultiReader(strings.NewReader("scrambled text")) },
+			func() io.Reader { return io.MultiReader(strings.NewReader("scrambled tent")) }, ByteUnit, false},
+		{"runes", func() io.Reader { return io.MultiReader(strings.NewReader("日本語のテキスト")) },
+			func() io.Reader { return io.MultiReader(strings.NewReader("テキストの日本語")) }, RuneUnit, true},
+	}
+	for _, tc := range readerCases {
+		ok, mismatch, err := IsAnagramReader(tc.r1(), tc.r2(), tc.unit)
+		status := "✅"
+		if err != nil || ok != tc.expected {
+			status = "❌"
+		}
+		detail := "anagrams"
+		if mismatch != nil {
+			detail = mismatch.String()
+		}
+		fmt.Printf("%-30s %s %s\n", tc.name+":", detail, status)
+	}
+
+	// Large blobs: about 11 MB each, streamed in 64 KB buffers
+	blob := strings.Repeat("scrambled backup data ", 1<<12)
+	large := func(extra string) io.Reader {
+		parts := []io.Reader{}
+		for i := 0; i < 1<<7; i++ {
+			parts = append(parts, strings.NewReader(blob))
+		}
+		return io.MultiReader(append(parts, strings.NewReader(extra))...)
+	}
+	ok, mismatch, err := IsAnagramReader(large("xy"), large("yz"), ByteUnit)
+	if err == nil && !ok {
+		fmt.Printf("Large blobs: not anagrams, %s\n", mismatch)
+	}
+	// A short reader against an 11 MB one: stops a few bytes after the short one ends
+	ok, mismatch, err = IsAnagramReader(io.MultiReader(strings.NewReader("scram")), large(""), ByteUnit)
+	if err == nil && !ok {
+		fmt.Printf("Short vs large: not anagrams, %s\n", mismatch)
+	}
+
+	fmt.Println("\n=== Word-Parallel (SWAR) Counting vs Array, HashMap and Sort ===")
+	// Correctness first: SWAR must agree with isAnagram, including non-letter fallbacks
+	swarDisagreements := 0
+	for trial := 0; trial < 20000; trial++ {
+		letters := make([]byte, rng.Intn(40))
+		for i := range letters {
+			letters[i] = byte('a' + rng.Intn(4))
+			if rng.Intn(50) == 0 {
+				letters[i] = "A{`@z"[rng.Intn(5)] // around the a-z boundaries
+			}
+		}
+		shuffled := slices.Clone(letters)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		if rng.Intn(3) == 0 && len(shuffled) > 0 {
+			shuffled[rng.Intn(len(shuffled))] = 'z'
+		}
+		if isAnagramSWAR(string(letters), string(shuffled)) != isAnagram(string(letters), string(shuffled)) {
+			swarDisagreements++
+		}
+	}
+	fmt.Printf("SWAR vs array on 20000 random inputs: %d disagreements\n", swarDisagreements)
+
+	// Anagram pairs of each size: a random block and a shuffled copy, repeated
+	block := make([]byte, 1024)
+	for i := range block {
+		block[i] = byte('a' + rng.Intn(26))
+	}
+	shuffledBlock := slices.Clone(block)
+	rng.Shuffle(len(shuffledBlock), func(i, j int) {
+		shuffledBlock[i], shuffledBlock[j] = shuffledBlock[j], shuffledBlock[i]
+	})
+
+	approaches := []struct {
+		name string
+		fn   func(string, string) bool
+	}{
+		{"SWAR", isAnagramSWAR},
+		{"Array", isAnagram},
+		{"HashMap", isAnagramHashMap},
+		{"Sort", isAnagramSort},
+	}
+	fmt.Printf("%-8s", "Size")
+	for _, a := range approaches {
+		fmt.Printf(" | %-12s", a.name)
+	}
+	fmt.Println()
+	for _, size := range []int{1 << 10, 1 << 20, 100 << 20} {
+		s1 := strings.Repeat(string(block), size/len(block))
+		s2 := strings.Repeat(string(shuffledBlock), size/len(block))
+		rounds := max(1, (8<<20)/size) // about 8 MB of input per measurement, 1 round at 100 MB
+
+		fmt.Printf("%-8s", byteSize(size))
+		for _, a := range approaches {
+			start := time.Now()
+			for r := 0; r < rounds; r++ {
+				if !a.fn(s1, s2) {
+					fmt.Print("❌")
+				}
+			}
+			elapsed := time.Since(start) / time.Duration(rounds)
+			throughput := float64(size) / elapsed.Seconds() / (1 << 20)
+			fmt.Printf(" | %-12s", fmt.Sprintf("%.0f MB/s", throughput))
+		}
+		fmt.Println()
+	}
+
 	// Performance explanation
 	fmt.Println("\n=== Algorithm Explanation ===")
 	fmt.Println("1. Array Approach (FASTEST - Recommended for LeetCode):")
@@ -133,4 +976,21 @@ func main() {
 	fmt.Println("   - Time: O(n log n), Space: O(n)")
 	fmt.Println("   - Sort both strings and compare")
 	fmt.Println("   - Simple but least efficient")
+
+	fmt.Println("\n4. Word-Parallel (SWAR) Approach:")
+	fmt.Println("   - Time: O(n), Space: O(1)")
+	fmt.Println("   - Validates 8 bytes per step with uint64 arithmetic")
+	fmt.Println("   - 4 rotating counter tables avoid back-to-back updates of one counter")
+	fmt.Println("   - Pays off on long lowercase strings; short ones are dominated by setup")
+}
+
+// byteSize formats a size like 1KB, 1MB, 100MB
+func byteSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%dMB", n>>20)
+	case n >= 1<<10:
+		return fmt.Sprintf("%dKB", n>>10)
+	}
+	return fmt.Sprintf("%dB", n)
 }